@@ -7,9 +7,13 @@ import (
     {{ $group.Package.Alias }} {{ $group.Package.Path | quote }}
     {{- end }}
     "github.com/onosproject/helm-go/pkg/kubernetes/config"
+    "github.com/onosproject/helm-go/pkg/kubernetes/dynamic"
     "github.com/onosproject/helm-go/pkg/kubernetes/resource"
-	"k8s.io/client-go/rest"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubedynamic "k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 // New returns a new Kubernetes client for the current namespace
@@ -26,27 +30,63 @@ func NewOrDie() Client {
 	return client
 }
 
-// NewForNamespace returns a new Kubernetes client for the given namespace
-func NewForNamespace(namespace string) (Client, error) {
-	kubernetesConfig, err := config.GetRestConfig()
-	if err != nil {
-		return nil, err
+// Option is a Kubernetes client option
+type Option func(*options)
+
+type options struct {
+	clientset  kubernetes.Interface
+	clientsets *resource.Clientsets
+	cache      *resource.Cache
+	namespaces []string
+}
+
+// WithClientset returns an option that shares the given clientset rather than creating a new one. The client still
+// creates its own dynamic client and discovery cache; use WithClientsets to share those as well.
+func WithClientset(clientset kubernetes.Interface) Option {
+	return func(options *options) {
+		options.clientset = clientset
 	}
-	kubernetesClient, err := kubernetes.NewForConfig(kubernetesConfig)
-	if err != nil {
-    	return nil, err
+}
+
+// WithClientsets returns an option that shares the given clientsets -- the clientset, dynamic client, discovery
+// cache and typed clientsets -- rather than creating new ones, e.g. WithClientsets(resource.GetClientsets(parent))
+func WithClientsets(clientsets *resource.Clientsets) Option {
+	return func(options *options) {
+		options.clientsets = clientsets
+	}
+}
+
+// WithCache returns an option that serves Get and List requests from the given informer cache
+func WithCache(cache *resource.Cache) Option {
+	return func(options *options) {
+		options.cache = cache
+	}
+}
+
+// WithNamespaces returns an option that reads namespaced resources from the given namespaces in addition to the
+// client namespace
+func WithNamespaces(namespaces ...string) Option {
+	return func(options *options) {
+		options.namespaces = append(options.namespaces, namespaces...)
+	}
+}
+
+func newOptions(opts ...Option) options {
+	options := options{}
+	for _, opt := range opts {
+		opt(&options)
 	}
-    return &{{ .Types.Struct }}{
-        namespace: namespace,
-        config:    kubernetesConfig,
-        client:    kubernetesClient,
-        filter:    resource.NoFilter,
-    }, nil
+	return options
+}
+
+// NewForNamespace returns a new Kubernetes client for the given namespace
+func NewForNamespace(namespace string, opts ...Option) (Client, error) {
+	return NewFiltered(namespace, resource.NoFilter, opts...)
 }
 
 // NewForNamespaceOrDie returns a new Kubernetes client for the given namespace
-func NewForNamespaceOrDie(namespace string) Client {
-	client, err := NewForNamespace(namespace)
+func NewForNamespaceOrDie(namespace string, opts ...Option) Client {
+	client, err := NewForNamespace(namespace, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -58,61 +98,110 @@ type {{ .Types.Interface }} interface {
 	// Namespace returns the client namespace
 	Namespace() string
 
+	// Namespaces returns the namespaces from which the client reads namespaced resources, starting with the client
+	// namespace. Cluster scoped resources are read cluster-wide.
+	Namespaces() []string
+
 	// Config returns the Kubernetes REST client configuration
 	Config() *rest.Config
 
 	// Clientset returns the client's Clientset
-	Clientset() *kubernetes.Clientset
+	Clientset() kubernetes.Interface
+
+	// DynamicClient returns the client's dynamic client
+	DynamicClient() kubedynamic.Interface
 
+	// RESTMapping returns the REST mapping for the given kind, refreshing discovery if the kind is unknown
+	RESTMapping(kind schema.GroupVersionKind) (*meta.RESTMapping, error)
+
+	// TypedClientset returns the client's typed clientset with the given name, creating it if necessary
+	TypedClientset(name string, newClientset resource.ClientsetFunc) (interface{}, error)
+
+	// Dynamic returns a reader and writer for unstructured objects of the given kind
+	Dynamic(kind schema.GroupVersionKind) dynamic.Client
+
+	// All lists the objects of every kind visible to the client
+	All() ([]*dynamic.Object, error)
     {{- range $name, $group := .Groups }}
     {{ $group.Names.Proper }}() {{ $group.Package.Alias }}.{{ $group.Types.Interface }}
     {{- end }}
 }
 
 // NewFiltered returns a new filtered Kubernetes client
-func NewFiltered(namespace string, filter resource.Filter) ({{ .Types.Interface }}, error) {
-	kubernetesConfig, err := config.GetRestConfig()
-	if err != nil {
-		return nil, err
-	}
-	kubernetesClient, err := kubernetes.NewForConfig(kubernetesConfig)
-	if err != nil {
-		return nil, err
+func NewFiltered(namespace string, filter resource.Filter, opts ...Option) ({{ .Types.Interface }}, error) {
+	options := newOptions(opts...)
+	clientsets := options.clientsets
+	if clientsets == nil {
+		kubernetesConfig, err := config.GetRestConfig()
+		if err != nil {
+			return nil, err
+		}
+		clientsets, err = resource.NewClientsets(kubernetesConfig, options.clientset)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return &{{ .Types.Struct }}{
-		namespace: namespace,
-		config:    kubernetesConfig,
-		client:    kubernetesClient,
-		filter:    filter,
+		Clientsets: clientsets,
+		namespace:  namespace,
+		namespaces: getNamespaces(namespace, options.namespaces),
+		filter:     filter,
+		cache:      options.cache,
 	}, nil
 }
 
 // NewFilteredOrDie returns a new filtered Kubernetes client
-func NewFilteredOrDie(namespace string, filter resource.Filter) {{ .Types.Interface }} {
-	client, err := NewFiltered(namespace, filter)
+func NewFilteredOrDie(namespace string, filter resource.Filter, opts ...Option) {{ .Types.Interface }} {
+	client, err := NewFiltered(namespace, filter, opts...)
 	if err != nil {
 		panic(err)
 	}
 	return client
 }
 
+// getNamespaces returns the client namespace followed by each distinct additional namespace
+func getNamespaces(namespace string, additional []string) []string {
+	namespaces := []string{namespace}
+	for _, ns := range additional {
+		unique := ns != ""
+		for _, existing := range namespaces {
+			if ns == existing {
+				unique = false
+			}
+		}
+		if unique {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
 type {{ .Types.Struct }} struct {
-	namespace string
-	config    *rest.Config
-	client    *kubernetes.Clientset
-	filter    resource.Filter
+	*resource.Clientsets
+	namespace  string
+	namespaces []string
+	filter     resource.Filter
+	cache      *resource.Cache
 }
 
 func (c *{{ .Types.Struct }}) Namespace() string {
 	return c.namespace
 }
 
-func (c *{{ .Types.Struct }}) Config() *rest.Config {
-	return c.config
+func (c *{{ .Types.Struct }}) Namespaces() []string {
+	return c.namespaces
+}
+
+func (c *{{ .Types.Struct }}) Cache() *resource.Cache {
+	return c.cache
+}
+
+func (c *{{ .Types.Struct }}) Dynamic(kind schema.GroupVersionKind) dynamic.Client {
+	return dynamic.NewClient(c, c.filter, kind)
 }
 
-func (c *{{ .Types.Struct }}) Clientset() *kubernetes.Clientset {
-	return c.client
+func (c *{{ .Types.Struct }}) All() ([]*dynamic.Object, error) {
+	return dynamic.All(c, c.filter)
 }
 
 {{- range $name, $group := .Groups }}
@@ -20,7 +20,6 @@ import "path"
 type FilterOptions struct {
 	Location Location
 	Package  Package
-	Groups   map[string]*GroupOptions
 }
 
 func generateFilters(options FilterOptions) error {
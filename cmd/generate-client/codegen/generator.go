@@ -15,12 +15,11 @@
 package codegen
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/iancoleman/strcase"
-	"github.com/joncalhoun/pipe"
-	"io"
+	"go/format"
 	"os"
-	"os/exec"
 	"path"
 	"runtime"
 	"strings"
@@ -29,9 +28,10 @@ import (
 
 // Config is the code generator configuration
 type Config struct {
-	Path      string     `yaml:"path,omitempty"`
-	Package   string     `yaml:"package,omitempty"`
-	Resources []Resource `yaml:"resources"`
+	Path      string              `yaml:"path,omitempty"`
+	Package   string              `yaml:"package,omitempty"`
+	Resources []Resource          `yaml:"resources"`
+	Owners    map[string][]string `yaml:"owners,omitempty"`
 }
 
 // Resource is a code generator resource
@@ -49,6 +49,11 @@ type Resource struct {
 
 // Generate generates a Helm API for the given configuration
 func Generate(config Config) error {
+	resources, err := applyOwners(config.Resources, config.Owners)
+	if err != nil {
+		return err
+	}
+	config.Resources = resources
 	options := getOptionsFromConfig(config)
 	return generateClient(options)
 }
@@ -98,19 +103,20 @@ func getTemplate(name string) *template.Template {
 
 func generateTemplate(t *template.Template, outputFile string, options interface{}) error {
 	fmt.Println(fmt.Sprintf("Generating file %s from template %s", outputFile, t.Name()))
-	rc, wc, _ := pipe.Commands(
-		exec.Command("gofmt"),
-	)
-	if err := t.Execute(wc, options); err != nil {
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, options); err != nil {
+		return err
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
 		return err
 	}
-	wc.Close()
 	file, err := openFile(outputFile)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
-	_, err = io.Copy(file, rc)
+	_, err = file.Write(source)
 	return err
 }
 
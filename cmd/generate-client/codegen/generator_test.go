@@ -0,0 +1,128 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+const fixtureAPI = "github.com/onosproject/helm-go/cmd/generate-client/codegen/testdata/api"
+
+// schemeClientTest is a test for the generated scheme-based client, run against a stand-in for the API server
+const schemeClientTest = `package v1beta1
+
+import (
+	"encoding/json"
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testClient struct {
+	*resource.Clientsets
+}
+
+func (c *testClient) Namespace() string {
+	return "test"
+}
+
+func TestSchemeClient(t *testing.T) {
+	paths := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		database := map[string]interface{}{
+			"apiVersion": "cloud.atomix.io/v1beta1",
+			"kind":       "Database",
+			"metadata":   metav1.ObjectMeta{Namespace: "test", Name: "raft"},
+		}
+		switch req.URL.Path {
+		case "/apis/cloud.atomix.io/v1beta1/namespaces/test/databases":
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"apiVersion": "cloud.atomix.io/v1beta1",
+				"kind":       "DatabaseList",
+				"items":      []interface{}{database},
+			}))
+		case "/apis/cloud.atomix.io/v1beta1/namespaces/test/databases/raft":
+			assert.NoError(t, json.NewEncoder(w).Encode(database))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	clientsets, err := resource.NewClientsets(&rest.Config{Host: server.URL, QPS: -1}, nil)
+	assert.NoError(t, err)
+	client := NewClient(&testClient{clientsets}, resource.NoFilter)
+
+	database, err := client.Databases().Get("raft")
+	assert.NoError(t, err)
+	assert.Equal(t, "raft", database.Name)
+	databases, err := client.Databases().List()
+	assert.NoError(t, err)
+	assert.Len(t, databases, 1)
+	assert.Equal(t, []string{
+		"/apis/cloud.atomix.io/v1beta1/namespaces/test/databases/raft",
+		"/apis/cloud.atomix.io/v1beta1/namespaces/test/databases",
+	}, paths)
+}
+`
+
+func TestGenerateSchemeClient(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping build of generated client in short mode")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+
+	// The client is generated in testdata so it builds against the module's dependencies
+	dir, err := ioutil.TempDir("testdata", "client")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	config := Config{
+		Path:    dir,
+		Package: "github.com/onosproject/helm-go/cmd/generate-client/codegen/" + filepath.ToSlash(dir),
+		Resources: []Resource{
+			{
+				Group:      "cloud.atomix.io",
+				Version:    "v1beta1",
+				Kind:       "Database",
+				ListKind:   "DatabaseList",
+				PluralKind: "Databases",
+				API:        fixtureAPI,
+			},
+		},
+	}
+	options := getOptionsFromConfig(config)
+	assert.True(t, options.Groups["cloud.atomix.iov1beta1"].Client.Scheme)
+	assert.NoError(t, Generate(config))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cloud", "v1beta1", "client_test.go"), []byte(schemeClientTest), 0644))
+
+	cmd := exec.Command(goTool, "test", "-count=1", "./"+filepath.ToSlash(dir)+"/...")
+	output, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(output))
+}
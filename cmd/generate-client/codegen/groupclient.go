@@ -22,6 +22,7 @@ type GroupOptions struct {
 	Package   Package
 	Group     string
 	Version   string
+	Client    ResourceClientKind
 	Types     GroupTypes
 	Names     GroupNames
 	Resources map[string]*ResourceOptions
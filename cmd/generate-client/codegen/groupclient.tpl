@@ -4,6 +4,13 @@ package {{ .Package.Name }}
 
 import (
     "github.com/onosproject/helm-go/pkg/kubernetes/resource"
+    {{ .Client.Package.Alias }} {{ .Client.Package.Path | quote }}
+    {{- if .Client.Scheme }}
+    "k8s.io/apimachinery/pkg/runtime"
+    "k8s.io/apimachinery/pkg/runtime/schema"
+    "k8s.io/apimachinery/pkg/runtime/serializer"
+    {{- end }}
+    "k8s.io/client-go/rest"
 )
 
 type {{ .Types.Interface }} interface {
@@ -27,3 +34,51 @@ type {{ .Types.Struct }} struct {
     {{ $resource.Client.Types.Interface }}
     {{- end }}
 }
+
+{{- if .Client.Scheme }}
+func getRESTClient(client resource.Client) (rest.Interface, error) {
+	restClient, err := client.TypedClientset({{ .Client.Package.Path | quote }}, func(config *rest.Config) (interface{}, error) {
+		scheme := runtime.NewScheme()
+		if err := {{ .Client.Package.Alias }}.AddToScheme(scheme); err != nil {
+			return nil, err
+		}
+		config = rest.CopyConfig(config)
+		config.GroupVersion = &schema.GroupVersion{
+			Group:   {{ .Group | quote }},
+			Version: {{ .Version | quote }},
+		}
+		config.APIPath = "/apis"
+		config.NegotiatedSerializer = serializer.NewCodecFactory(scheme).WithoutConversion()
+		if config.UserAgent == "" {
+			config.UserAgent = rest.DefaultKubernetesUserAgent()
+		}
+		return rest.RESTClientFor(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restClient.(rest.Interface), nil
+}
+{{- else }}
+func getClientset(client resource.Client) ({{ .Client.Package.Alias }}.Interface, error) {
+    {{- if eq .Client.Package.Path "k8s.io/client-go/kubernetes" }}
+	return client.Clientset(), nil
+    {{- else }}
+	typed, err := client.TypedClientset({{ .Client.Package.Path | quote }}, func(config *rest.Config) (interface{}, error) {
+		return {{ .Client.Package.Alias }}.NewForConfig(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return typed.({{ .Client.Package.Alias }}.Interface), nil
+    {{- end }}
+}
+
+func getRESTClient(client resource.Client) (rest.Interface, error) {
+	clientset, err := getClientset(client)
+	if err != nil {
+		return nil, err
+	}
+	return clientset.{{ .Names.Proper }}().RESTClient(), nil
+}
+{{- end }}
@@ -0,0 +1,296 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// customResourceDefinition is the subset of the v1 and v1beta1 CustomResourceDefinition schemas used to
+// generate resources
+type customResourceDefinition struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Group   string `json:"group"`
+		Version string `json:"version"`
+		Scope   string `json:"scope"`
+		Names   struct {
+			Kind     string `json:"kind"`
+			ListKind string `json:"listKind"`
+			Plural   string `json:"plural"`
+		} `json:"names"`
+		Versions []struct {
+			Name   string `json:"name"`
+			Served bool   `json:"served"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+// GetResourcesFromCRDs returns the resources defined by the CustomResourceDefinitions in the given files. If a path
+// is a directory, e.g. a chart's crds/ directory, all the YAML and JSON files in the directory are read.
+func GetResourcesFromCRDs(paths ...string) ([]Resource, error) {
+	resources := make([]Resource, 0)
+	for _, path := range paths {
+		files, err := getCRDFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			crds, err := decodeCRDs(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode %s: %v", file, err)
+			}
+			for _, crd := range crds {
+				resources = append(resources, getCRDResources(crd)...)
+			}
+		}
+	}
+	return resources, nil
+}
+
+func getCRDFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	} else if !info.IsDir() {
+		return []string{path}, nil
+	}
+	files := make([]string, 0)
+	err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		switch filepath.Ext(file) {
+		case ".yaml", ".yml", ".json":
+			if !info.IsDir() {
+				files = append(files, file)
+			}
+		}
+		return nil
+	})
+	return files, err
+}
+
+func decodeCRDs(data []byte) ([]customResourceDefinition, error) {
+	crds := make([]customResourceDefinition, 0)
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		crd := customResourceDefinition{}
+		if err := decoder.Decode(&crd); err == io.EOF {
+			return crds, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if crd.Kind == "CustomResourceDefinition" {
+			crds = append(crds, crd)
+		}
+	}
+}
+
+func getCRDResources(crd customResourceDefinition) []Resource {
+	versions := make([]string, 0, len(crd.Spec.Versions))
+	for _, version := range crd.Spec.Versions {
+		if version.Served {
+			versions = append(versions, version.Name)
+		}
+	}
+	if len(versions) == 0 && crd.Spec.Version != "" {
+		versions = append(versions, crd.Spec.Version)
+	}
+
+	listKind := crd.Spec.Names.ListKind
+	if listKind == "" {
+		listKind = crd.Spec.Names.Kind + "List"
+	}
+
+	resources := make([]Resource, 0, len(versions))
+	for _, version := range versions {
+		resources = append(resources, Resource{
+			Group:      crd.Spec.Group,
+			Version:    version,
+			Kind:       crd.Spec.Names.Kind,
+			ListKind:   listKind,
+			PluralKind: getPluralKind(crd.Spec.Names.Kind, crd.Spec.Names.Plural),
+			Scope:      getScope(crd.Spec.Scope == "Namespaced"),
+		})
+	}
+	return resources
+}
+
+// GetResourcesFromDiscovery returns the resources listed in an offline discovery dump. The file contains either a
+// single APIResourceList, as served by the /api/<version> and /apis/<group>/<version> endpoints, or an array of them.
+func GetResourcesFromDiscovery(path string) ([]Resource, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceLists := make([]metav1.APIResourceList, 0)
+	if err := json.Unmarshal(data, &resourceLists); err != nil {
+		resourceList := metav1.APIResourceList{}
+		if err := json.Unmarshal(data, &resourceList); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %v", path, err)
+		}
+		resourceLists = append(resourceLists, resourceList)
+	}
+
+	resources := make([]Resource, 0)
+	for _, resourceList := range resourceLists {
+		groupVersion, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, apiResource := range resourceList.APIResources {
+			if strings.Contains(apiResource.Name, "/") || !sets.NewString(apiResource.Verbs...).Has("list") {
+				continue
+			}
+			resources = append(resources, Resource{
+				Group:      groupVersion.Group,
+				Version:    groupVersion.Version,
+				Kind:       apiResource.Kind,
+				ListKind:   apiResource.Kind + "List",
+				PluralKind: getPluralKind(apiResource.Kind, apiResource.Name),
+				Scope:      getScope(apiResource.Namespaced),
+			})
+		}
+	}
+	return resources, nil
+}
+
+// builtInGroups is the set of API groups whose types are provided by the k8s.io/api module
+var builtInGroups = sets.NewString(
+	"",
+	"admissionregistration.k8s.io",
+	"apps",
+	"auditregistration.k8s.io",
+	"authentication.k8s.io",
+	"authorization.k8s.io",
+	"autoscaling",
+	"batch",
+	"certificates.k8s.io",
+	"coordination.k8s.io",
+	"discovery.k8s.io",
+	"events.k8s.io",
+	"extensions",
+	"flowcontrol.apiserver.k8s.io",
+	"imagepolicy.k8s.io",
+	"networking.k8s.io",
+	"node.k8s.io",
+	"policy",
+	"rbac.authorization.k8s.io",
+	"scheduling.k8s.io",
+	"settings.k8s.io",
+	"storage.k8s.io",
+)
+
+// IsBuiltInGroup returns whether the types for the given API group are provided by k8s.io/api. Other groups, including
+// Kubernetes groups served by extension API servers such as apiextensions.k8s.io or metrics.k8s.io, are not.
+func IsBuiltInGroup(group string) bool {
+	return builtInGroups.Has(group)
+}
+
+// getPluralKind returns the plural form of the given kind, e.g. NetworkPolicies for NetworkPolicy, using the
+// lower case plural resource name
+func getPluralKind(kind, plural string) string {
+	lowerKind := strings.ToLower(kind)
+	i := 0
+	for i < len(lowerKind) && i < len(plural) && lowerKind[i] == plural[i] {
+		i++
+	}
+	return kind[:i] + plural[i:]
+}
+
+func getScope(namespaced bool) string {
+	if namespaced {
+		return "Namespaced"
+	}
+	return "Cluster"
+}
+
+// MergeResources appends the given resources to the configuration, skipping resources that are already configured
+func MergeResources(config Config, resources ...Resource) Config {
+	kinds := sets.NewString()
+	for _, resource := range config.Resources {
+		kinds.Insert(getKindKey(resource))
+	}
+	for _, resource := range resources {
+		if !kinds.Has(getKindKey(resource)) {
+			kinds.Insert(getKindKey(resource))
+			config.Resources = append(config.Resources, resource)
+		}
+	}
+	return config
+}
+
+// applyOwners adds the kinds owned by each resource in the ownership map to the resource's sub-resources. Keys and
+// values in the map are kinds in the form <group>/<version>/<kind>, or v1/<kind> for the core group.
+func applyOwners(resources []Resource, owners map[string][]string) ([]Resource, error) {
+	for i, resource := range resources {
+		owned, ok := owners[getKindKey(resource)]
+		if !ok {
+			continue
+		}
+		kinds := sets.NewString()
+		for _, subResource := range resource.SubResources {
+			kinds.Insert(getKindKey(subResource))
+		}
+		for _, key := range owned {
+			kind, err := parseKindKey(key)
+			if err != nil {
+				return nil, err
+			}
+			if !kinds.Has(getKindKey(kind)) {
+				kinds.Insert(getKindKey(kind))
+				resources[i].SubResources = append(resources[i].SubResources, kind)
+			}
+		}
+	}
+	return resources, nil
+}
+
+func getKindKey(resource Resource) string {
+	return fmt.Sprintf("%s/%s", schema.GroupVersion{Group: resource.Group, Version: resource.Version}.String(), resource.Kind)
+}
+
+func parseKindKey(key string) (Resource, error) {
+	i := strings.LastIndex(key, "/")
+	if i == -1 {
+		return Resource{}, fmt.Errorf("invalid kind %s", key)
+	}
+	groupVersion, err := schema.ParseGroupVersion(key[:i])
+	if err != nil {
+		return Resource{}, err
+	}
+	return Resource{
+		Group:   groupVersion.Group,
+		Version: groupVersion.Version,
+		Kind:    key[i+1:],
+	}, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const databaseCRD = `
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: databases.cloud.atomix.io
+spec:
+  group: cloud.atomix.io
+  version: v1beta2
+  scope: Namespaced
+  names:
+    kind: Database
+    listKind: DatabaseList
+    plural: databases
+    singular: database
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+`
+
+const partitionCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: partitionpolicies.cloud.atomix.io
+spec:
+  group: cloud.atomix.io
+  scope: Cluster
+  names:
+    kind: PartitionPolicy
+    plural: partitionpolicies
+  versions:
+    - name: v1beta1
+      served: false
+    - name: v1beta2
+      served: true
+`
+
+func TestGetResourcesFromCRDs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "crds")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "database.yaml"), []byte(databaseCRD), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "partitionpolicy.yml"), []byte(partitionCRD), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("# CRDs"), 0644))
+
+	resources, err := GetResourcesFromCRDs(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []Resource{
+		{
+			Group:      "cloud.atomix.io",
+			Version:    "v1beta2",
+			Kind:       "Database",
+			ListKind:   "DatabaseList",
+			PluralKind: "Databases",
+			Scope:      "Namespaced",
+		},
+		{
+			Group:      "cloud.atomix.io",
+			Version:    "v1beta2",
+			Kind:       "PartitionPolicy",
+			ListKind:   "PartitionPolicyList",
+			PluralKind: "PartitionPolicies",
+			Scope:      "Cluster",
+		},
+	}, resources)
+}
+
+const discovery = `[
+  {
+    "groupVersion": "v1",
+    "resources": [
+      {"name": "endpoints", "namespaced": true, "kind": "Endpoints", "verbs": ["get", "list", "watch"]},
+      {"name": "pods", "namespaced": true, "kind": "Pod", "verbs": ["get", "list", "watch"]},
+      {"name": "pods/log", "namespaced": true, "kind": "Pod", "verbs": ["get"]},
+      {"name": "bindings", "namespaced": true, "kind": "Binding", "verbs": ["create"]}
+    ]
+  },
+  {
+    "groupVersion": "apps/v1",
+    "resources": [
+      {"name": "replicasets", "namespaced": true, "kind": "ReplicaSet", "verbs": ["get", "list", "watch"]}
+    ]
+  }
+]`
+
+func TestGetResourcesFromDiscovery(t *testing.T) {
+	file, err := ioutil.TempFile("", "discovery")
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(discovery)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	resources, err := GetResourcesFromDiscovery(file.Name())
+	assert.NoError(t, err)
+	assert.Len(t, resources, 3)
+	assert.Equal(t, Resource{
+		Group:      "",
+		Version:    "v1",
+		Kind:       "Endpoints",
+		ListKind:   "EndpointsList",
+		PluralKind: "Endpoints",
+		Scope:      "Namespaced",
+	}, resources[0])
+	assert.Equal(t, "Pods", resources[1].PluralKind)
+	assert.Equal(t, "apps", resources[2].Group)
+
+	config := MergeResources(Config{Resources: resources[:1]}, resources...)
+	assert.Len(t, config.Resources, 3)
+
+	resources, err = applyOwners(config.Resources, map[string][]string{
+		"apps/v1/ReplicaSet": {"v1/Pod"},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, resources[0].SubResources)
+	assert.Equal(t, []Resource{{Version: "v1", Kind: "Pod"}}, resources[2].SubResources)
+
+	_, err = applyOwners(config.Resources, map[string][]string{
+		"apps/v1/ReplicaSet": {"Pod"},
+	})
+	assert.Error(t, err)
+}
+
+func TestIsBuiltInGroup(t *testing.T) {
+	assert.True(t, IsBuiltInGroup(""))
+	assert.True(t, IsBuiltInGroup("apps"))
+	assert.True(t, IsBuiltInGroup("networking.k8s.io"))
+	assert.False(t, IsBuiltInGroup("apiextensions.k8s.io"))
+	assert.False(t, IsBuiltInGroup("metrics.k8s.io"))
+	assert.False(t, IsBuiltInGroup("snapshot.storage.k8s.io"))
+	assert.False(t, IsBuiltInGroup("atomix.io"))
+}
@@ -61,6 +61,36 @@ func getOptionsFromConfig(config Config) ClientOptions {
 			group = group[:index]
 		}
 
+		api := resource.API
+		if api == "" {
+			api = "k8s.io/api"
+		}
+		pkg := fmt.Sprintf("%s/%s/%s", api, group, resource.Version)
+
+		clientPkg := resource.Client
+		if clientPkg == "" {
+			clientPkg = "k8s.io/client-go/kubernetes"
+		}
+		client := ResourceClientKind{
+			Package: Package{
+				Name:  path.Base(clientPkg),
+				Path:  clientPkg,
+				Alias: path.Base(clientPkg),
+			},
+		}
+
+		// Custom resources without a generated clientset are read with a REST client built from the API scheme
+		if resource.Client == "" && api != "k8s.io/api" {
+			client = ResourceClientKind{
+				Package: Package{
+					Name:  path.Base(pkg),
+					Path:  pkg,
+					Alias: fmt.Sprintf("%s%s", group, resource.Version),
+				},
+				Scheme: true,
+			}
+		}
+
 		versionOpts, ok := options.Groups[fmt.Sprintf("%s%s", resource.Group, resource.Version)]
 		if !ok {
 			versionOpts = &GroupOptions{
@@ -75,6 +105,7 @@ func getOptionsFromConfig(config Config) ClientOptions {
 				},
 				Group:   resource.Group,
 				Version: resource.Version,
+				Client:  client,
 				Types: GroupTypes{
 					Interface: "Client",
 					Struct:    "client",
@@ -89,17 +120,6 @@ func getOptionsFromConfig(config Config) ClientOptions {
 
 		_, ok = versionOpts.Resources[resource.Kind]
 		if !ok {
-			api := resource.API
-			if api == "" {
-				api = "k8s.io/api"
-			}
-			pkg := fmt.Sprintf("%s/%s/%s", api, group, resource.Version)
-
-			client := resource.Client
-			if client == "" {
-				client = "k8s.io/client-go/kubernetes"
-			}
-
 			resourceOpts := &ResourceOptions{
 				Client: &ResourceClientOptions{
 					Location: Location{
@@ -141,13 +161,7 @@ func getOptionsFromConfig(config Config) ClientOptions {
 						Path:  fmt.Sprintf("%s/%s/%s", config.Package, group, resource.Version),
 						Alias: fmt.Sprintf("%s%s", group, resource.Version),
 					},
-					Client: ResourceClientKind{
-						Package: Package{
-							Name:  path.Base(client),
-							Path:  client,
-							Alias: path.Base(client),
-						},
-					},
+					Client: client,
 					Kind: ResourceObjectKind{
 						Package: Package{
 							Name:  path.Base(pkg),
@@ -231,7 +245,6 @@ func getOptionsFromConfig(config Config) ClientOptions {
 			Path:  fmt.Sprintf("%s/filter", config.Package),
 			Alias: "filter",
 		},
-		Groups: options.Groups,
 	}
 	return options
 }
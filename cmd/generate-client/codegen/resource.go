@@ -49,6 +49,7 @@ type ResourceObjectKind struct {
 // ResourceClientKind contains information about a resource client
 type ResourceClientKind struct {
 	Package Package
+	Scheme  bool
 }
 
 // ResourceObjectTypes contains types for generating a resource object
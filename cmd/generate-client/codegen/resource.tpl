@@ -9,7 +9,8 @@ package {{ $resource.Package.Name }}
 import (
     "github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	{{ .Resource.Client.Package.Alias }} {{ .Resource.Client.Package.Path | quote }}
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	{{ .Resource.Kind.Package.Alias }} {{ .Resource.Kind.Package.Path | quote }}
     {{- range $ref := $resource.References }}
     {{- if not (eq $ref.Reference.Package.Path $resource.Package.Path) }}
@@ -36,6 +37,7 @@ var {{ $resource.Types.Resource }} = resource.Type{
 }
 
 func New{{ $resource.Types.Struct }}({{ $name }} *{{ $kind }}, client resource.Client) *{{ $resource.Types.Struct }} {
+	client = resource.ForNamespace(client, {{ $name }}.Namespace)
 	return &{{ $resource.Types.Struct }}{
 		Resource: resource.NewResource({{ $name }}.ObjectMeta, {{ .Resource.Types.Kind }}, client),
 		Object: {{ $name }},
@@ -62,12 +64,11 @@ type {{ $resource.Types.Struct }} struct {
 }
 
 func (r *{{ $resource.Types.Struct }}) Delete() error {
-    client, err := {{ .Resource.Client.Package.Alias }}.NewForConfig(r.Config())
+    client, err := getRESTClient(r.Client)
     if err != nil {
         return err
     }
-	return client.{{ .Group.Names.Proper }}().
-        RESTClient().
+	return client.
 	    Delete().
 	    NamespaceIfScoped(r.Namespace, {{ .Resource.Types.Kind }}.Scoped).
 		Resource({{ .Resource.Types.Resource }}.Name).
@@ -77,3 +78,94 @@ func (r *{{ $resource.Types.Struct }}) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *{{ $resource.Types.Struct }}) Refresh() error {
+    client, err := getRESTClient(r.Client)
+    if err != nil {
+        return err
+    }
+	result := &{{ $kind }}{}
+	err = client.
+	    Get().
+	    NamespaceIfScoped(r.Namespace, {{ .Resource.Types.Kind }}.Scoped).
+		Resource({{ .Resource.Types.Resource }}.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *{{ $resource.Types.Struct }}) Patch(patchType types.PatchType, data []byte) error {
+    client, err := getRESTClient(r.Client)
+    if err != nil {
+        return err
+    }
+	result := &{{ $kind }}{}
+	err = client.
+	    Patch(patchType).
+	    NamespaceIfScoped(r.Namespace, {{ .Resource.Types.Kind }}.Scoped).
+		Resource({{ .Resource.Types.Resource }}.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *{{ $resource.Types.Struct }}) Update(mutate func(*{{ $kind }}) error) error {
+    client, err := getRESTClient(r.Client)
+    if err != nil {
+        return err
+    }
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		{{ $name }} := r.Object.DeepCopy()
+		if err := mutate({{ $name }}); err != nil {
+			return err
+		}
+		result := &{{ $kind }}{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, {{ .Resource.Types.Kind }}.Scoped).
+			Resource({{ .Resource.Types.Resource }}.Name).
+			Name(r.Name).
+			Body({{ $name }}).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *{{ $resource.Types.Struct }}) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *{{ $resource.Types.Struct }}) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *{{ $resource.Types.Struct }}) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *{{ $resource.Types.Struct }}) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
@@ -3,18 +3,31 @@
 package {{ .Reader.Package.Name }}
 
 import (
+	"context"
     "github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	{{ .Resource.Client.Package.Alias }} {{ .Resource.Client.Package.Path | quote }}
 	{{ .Resource.Kind.Package.Alias }} {{ .Resource.Kind.Package.Path | quote }}
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type {{ .Reader.Types.Interface }} interface {
 	Get(name string) (*{{ .Resource.Types.Struct }}, error)
-	List() ([]*{{ .Resource.Types.Struct }}, error)
+	List(opts ...resource.ListOption) ([]*{{ .Resource.Types.Struct }}, error)
+	ListPages(handler func([]*{{ .Resource.Types.Struct }}) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan {{ .Resource.Types.Struct }}Event, error)
+	WaitFor(ctx context.Context, predicate func({{ .Resource.Types.Struct }}Event) (bool, error)) ({{ .Resource.Types.Struct }}Event, error)
+}
+
+type {{ .Resource.Types.Struct }}Event struct {
+	Type resource.EventType
+	{{ .Resource.Types.Struct }} *{{ .Resource.Types.Struct }}
 }
 
 func New{{ .Reader.Types.Interface }}(client resource.Client, filter resource.Filter) {{ .Reader.Types.Interface }} {
@@ -34,62 +47,137 @@ type {{ .Reader.Types.Struct }} struct {
 {{- $listKind := (printf "%s.%s" .Resource.Kind.Package.Alias .Resource.Kind.ListKind) }}
 
 func (c *{{ .Reader.Types.Struct }}) Get(name string) (*{{ .Resource.Types.Struct }}, error) {
-    {{ $singular }} := &{{ $kind }}{}
-    client, err := {{ .Resource.Client.Package.Alias }}.NewForConfig(c.Config())
+	for _, namespace := range resource.GetNamespaces(c.Client, {{ .Resource.Types.Kind }}.Scoped) {
+		{{ $singular }}, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		ok, err := c.filter(metav1.GroupVersionKind{
+			Group:   {{ .Resource.Types.Kind }}.Group,
+			Version: {{ .Resource.Types.Kind }}.Version,
+			Kind:    {{ .Resource.Types.Kind }}.Kind,
+		}, {{ $singular }}.ObjectMeta)
+		if err != nil {
+			return nil, err
+		} else if ok {
+			return New{{ .Resource.Types.Struct }}({{ $singular }}, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    {{ .Resource.Types.Kind }}.Group,
+		Resource: {{ .Resource.Types.Resource }}.Name,
+	}, name)
+}
+
+func (c *{{ .Reader.Types.Struct }}) get(namespace, name string) (*{{ $kind }}, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, {{ .Resource.Types.Kind }}.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
+			return nil, errors.NewNotFound(schema.GroupResource{
+				Group:    {{ .Resource.Types.Kind }}.Group,
+				Resource: {{ .Resource.Types.Resource }}.Name,
+			}, name)
+		}
+		return object.(*{{ $kind }}).DeepCopy(), nil
+	}
+
+    object := &{{ $kind }}{}
+    client, err := getRESTClient(c.Client)
     if err != nil {
         return nil, err
     }
-	err = client.{{ .Group.Names.Proper }}().
-        RESTClient().
+	err = client.
 	    Get().
-	    NamespaceIfScoped(c.Namespace(), {{ .Resource.Types.Kind }}.Scoped).
+	    NamespaceIfScoped(namespace, {{ .Resource.Types.Kind }}.Scoped).
 		Resource({{ .Resource.Types.Resource }}.Name).
 		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into({{ $singular }})
+		Into(object)
 	if err != nil {
 		return nil, err
-	} else {
-        ok, err := c.filter(metav1.GroupVersionKind{
-            Group:   {{ .Resource.Types.Kind }}.Group,
-            Version: {{ .Resource.Types.Kind }}.Version,
-            Kind:    {{ .Resource.Types.Kind }}.Kind,
-        }, {{ $singular }}.ObjectMeta)
-        if err != nil {
-            return nil, err
-        } else if !ok {
-            return nil, errors.NewNotFound(schema.GroupResource{
-                Group:    {{ .Resource.Types.Kind }}.Group,
-                Resource: {{ .Resource.Types.Resource }}.Name,
-            }, name)
-        }
-    }
-	return New{{ .Resource.Types.Struct }}({{ $singular }}, c.Client), nil
+	}
+	return object, nil
 }
 
-func (c *{{ .Reader.Types.Struct }}) List() ([]*{{ .Resource.Types.Struct }}, error) {
-    list := &{{ $listKind }}{}
-    client, err := {{ .Resource.Client.Package.Alias }}.NewForConfig(c.Config())
-    if err != nil {
-        return nil, err
-    }
-	err = client.{{ .Group.Names.Proper }}().
-        RESTClient().
-	    Get().
-	    NamespaceIfScoped(c.Namespace(), {{ .Resource.Types.Kind }}.Scoped).
-		Resource({{ .Resource.Types.Resource }}.Name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(list)
+func (c *{{ .Reader.Types.Struct }}) List(opts ...resource.ListOption) ([]*{{ .Resource.Types.Struct }}, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, {{ .Resource.Types.Kind }}.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*{{ .Resource.Types.Struct }}, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *{{ .Reader.Types.Struct }}) ListPages(handler func([]*{{ .Resource.Types.Struct }}) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, {{ .Resource.Types.Kind }}.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *{{ .Reader.Types.Struct }}) list(namespace string, options metav1.ListOptions) ([]*{{ .Resource.Types.Struct }}, string, error) {
+	items, next, err := c.listItems(namespace, options)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
 	}
+	return results, next, nil
+}
 
-	results := make([]*{{ .Resource.Types.Struct }}, 0, len(list.Items))
-	for _, {{ $singular }} := range list.Items {
+func (c *{{ .Reader.Types.Struct }}) filterItems(items []{{ $kind }}) ([]*{{ .Resource.Types.Struct }}, error) {
+	results := make([]*{{ .Resource.Types.Struct }}, 0, len(items))
+	for _, {{ $singular }} := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   {{ .Resource.Types.Kind }}.Group,
 			Version: {{ .Resource.Types.Kind }}.Version,
@@ -104,3 +192,145 @@ func (c *{{ .Reader.Types.Struct }}) List() ([]*{{ .Resource.Types.Struct }}, er
 	}
 	return results, nil
 }
+
+func (c *{{ .Reader.Types.Struct }}) listItems(namespace string, options metav1.ListOptions) ([]{{ $kind }}, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]{{ $kind }}, 0, len(objects))
+			for _, object := range objects {
+				{{ $singular }} := object.(*{{ $kind }})
+				if selector.Matches(labels.Set({{ $singular }}.Labels)) {
+					items = append(items, *{{ $singular }}.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+    list := &{{ $listKind }}{}
+    client, err := getRESTClient(c.Client)
+    if err != nil {
+        return nil, "", err
+    }
+	err = client.
+	    Get().
+	    NamespaceIfScoped(namespace, {{ .Resource.Types.Kind }}.Scoped).
+		Resource({{ .Resource.Types.Resource }}.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *{{ .Reader.Types.Struct }}) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer({{ .Resource.Types.Kind }}, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &{{ $listKind }}{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, {{ .Resource.Types.Kind }}.Scoped).
+					Resource({{ .Resource.Types.Resource }}.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, {{ .Resource.Types.Kind }}.Scoped).
+					Resource({{ .Resource.Types.Resource }}.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &{{ $kind }}{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *{{ .Reader.Types.Struct }}) Watch(ctx context.Context) (<-chan {{ .Resource.Types.Struct }}Event, error) {
+    client, err := getRESTClient(c.Client)
+    if err != nil {
+        return nil, err
+    }
+	namespaces := resource.GetNamespaces(c.Client, {{ .Resource.Types.Kind }}.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, {{ .Resource.Types.Kind }}.Scoped).
+			Resource({{ .Resource.Types.Resource }}.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan {{ .Resource.Types.Struct }}Event)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			{{ $singular }}, ok := watchEvent.Object.(*{{ $kind }})
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   {{ .Resource.Types.Kind }}.Group,
+				Version: {{ .Resource.Types.Kind }}.Version,
+				Kind:    {{ .Resource.Types.Kind }}.Kind,
+			}, {{ $singular }}.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- {{ .Resource.Types.Struct }}Event{Type: watchEvent.Type, {{ .Resource.Types.Struct }}: New{{ .Resource.Types.Struct }}({{ $singular }}, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *{{ .Reader.Types.Struct }}) WaitFor(ctx context.Context, predicate func({{ .Resource.Types.Struct }}Event) (bool, error)) ({{ .Resource.Types.Struct }}Event, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return {{ .Resource.Types.Struct }}Event{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return {{ .Resource.Types.Struct }}Event{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return {{ .Resource.Types.Struct }}Event{}, ctx.Err()
+}
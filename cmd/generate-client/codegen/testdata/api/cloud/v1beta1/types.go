@@ -0,0 +1,75 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package v1beta1 is a fixture API package for custom resources without a generated clientset
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// SchemeGroupVersion is the group version of the fixture resources
+var SchemeGroupVersion = schema.GroupVersion{Group: "cloud.atomix.io", Version: "v1beta1"}
+
+var (
+	// SchemeBuilder registers the fixture resources
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	// AddToScheme adds the fixture resources to a scheme
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion, &Database{}, &DatabaseList{})
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
+
+// Database is a fixture custom resource
+type Database struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+}
+
+// DeepCopy copies the database
+func (d *Database) DeepCopy() *Database {
+	out := &Database{}
+	out.TypeMeta = d.TypeMeta
+	d.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	return out
+}
+
+// DeepCopyObject copies the database
+func (d *Database) DeepCopyObject() runtime.Object {
+	return d.DeepCopy()
+}
+
+// DatabaseList is a list of fixture custom resources
+type DatabaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Database `json:"items"`
+}
+
+// DeepCopyObject copies the database list
+func (l *DatabaseList) DeepCopyObject() runtime.Object {
+	out := &DatabaseList{}
+	out.TypeMeta = l.TypeMeta
+	l.ListMeta.DeepCopyInto(&out.ListMeta)
+	for _, item := range l.Items {
+		out.Items = append(out.Items, *item.DeepCopy())
+	}
+	return out
+}
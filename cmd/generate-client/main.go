@@ -15,6 +15,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"github.com/onosproject/helm-go/cmd/generate-client/codegen"
 	"github.com/spf13/cobra"
@@ -25,38 +26,101 @@ import (
 
 func main() {
 	cmd := &cobra.Command{
-		Use:  "helmit-generate",
-		Args: cobra.RangeArgs(1, 2),
+		Use:  "helmit-generate [config] [path]",
+		Args: cobra.RangeArgs(0, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			file, err := os.Open(args[0])
-			if err != nil {
-				return err
-			}
-			defer file.Close()
-
-			bytes, err := ioutil.ReadAll(file)
-			if err != nil {
-				return err
-			}
+			crds, _ := cmd.Flags().GetStringArray("crds")
+			discovery, _ := cmd.Flags().GetString("discovery")
 
+			// The configuration file may be omitted when resources are read from CRDs or discovery
 			config := codegen.Config{}
-			if err := yaml.Unmarshal(bytes, &config); err != nil {
-				return err
+			if (len(crds) == 0 && discovery == "") || len(args) == 2 {
+				if len(args) == 0 {
+					return errors.New("a configuration file is required")
+				}
+				if err := readYAML(args[0], &config); err != nil {
+					return err
+				}
+				args = args[1:]
 			}
 
-			if len(args) > 1 {
-				config.Path = args[1]
+			if len(args) > 0 {
+				config.Path = args[0]
 			}
 			pkg, _ := cmd.Flags().GetString("package")
 			if pkg != "" {
 				config.Package = pkg
 			}
+			if config.Package == "" {
+				return errors.New("a package is required; set it in the configuration file or with --package")
+			}
+
+			api, _ := cmd.Flags().GetString("api")
+			client, _ := cmd.Flags().GetString("client")
+			if len(crds) > 0 {
+				resources, err := codegen.GetResourcesFromCRDs(crds...)
+				if err != nil {
+					return err
+				}
+				for i := range resources {
+					resources[i].API = api
+					resources[i].Client = client
+				}
+				config = codegen.MergeResources(config, resources...)
+			}
+			if discovery != "" {
+				resources, err := codegen.GetResourcesFromDiscovery(discovery)
+				if err != nil {
+					return err
+				}
+				// Built-in kinds are read from the Kubernetes API packages
+				for i, resource := range resources {
+					if !codegen.IsBuiltInGroup(resource.Group) {
+						resources[i].API = api
+						resources[i].Client = client
+					}
+				}
+				config = codegen.MergeResources(config, resources...)
+			}
+
+			owners, _ := cmd.Flags().GetString("owners")
+			if owners != "" {
+				ownership := make(map[string][]string)
+				if err := readYAML(owners, &ownership); err != nil {
+					return err
+				}
+				if config.Owners == nil {
+					config.Owners = make(map[string][]string)
+				}
+				for owner, owned := range ownership {
+					config.Owners[owner] = append(config.Owners[owner], owned...)
+				}
+			}
 			return codegen.Generate(config)
 		},
 	}
 	cmd.Flags().StringP("package", "p", "", "the package in which to generate the code")
+	cmd.Flags().StringArray("crds", []string{}, "a CustomResourceDefinition file or directory, e.g. a chart's crds/ directory, from which to generate resources")
+	cmd.Flags().String("discovery", "", "a JSON file containing API discovery resource lists from which to generate resources")
+	cmd.Flags().String("owners", "", "a YAML file mapping owner kinds to the kinds they own, e.g. apps/v1/ReplicaSet: [v1/Pod]")
+	cmd.Flags().String("api", "", "the Go package containing the API types for custom resources")
+	cmd.Flags().String("client", "", "the Go package containing the clientset for custom resources")
 	if err := cmd.Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 }
+
+func readYAML(path string, value interface{}) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	bytes, err := ioutil.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(bytes, value)
+}
@@ -15,10 +15,9 @@
 package release
 
 import (
-	"errors"
 	"github.com/onosproject/helm-go/pkg/helm/config"
 	"github.com/onosproject/helm-go/pkg/helm/values"
-	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/action"
 )
 
 // NewClient returns a new release client
@@ -32,10 +31,12 @@ func NewClient(config *config.Config) Client {
 type Client interface {
 	// Namespace returns the release client namespace
 	Namespace() string
-	// Get gets a release
+	// Get gets the latest revision of a release
 	Get(name string) (*Release, error)
+	// GetRevision gets a specific revision of a release
+	GetRevision(name string, revision int) (*Release, error)
 	// List lists releases
-	List() ([]*Release, error)
+	List() *ListRequest
 	// Status gets the status of a release
 	Status(name string) (StatusReport, error)
 	// Install installs a release
@@ -57,39 +58,28 @@ func (c *releaseClient) Namespace() string {
 	return c.config.Namespace()
 }
 
-// Get gets a release
+// Get gets the latest revision of a release
 func (c *releaseClient) Get(name string) (*Release, error) {
-	list, err := c.config.Releases.List(func(r *release.Release) bool {
-		return r.Namespace == c.config.Namespace() && r.Name == name
-	})
-	if err != nil {
-		return nil, err
-	} else if len(list) == 0 {
-		return nil, errors.New("release not found")
-	} else if len(list) > 1 {
-		return nil, errors.New("release is ambiguous")
-	}
-	return getRelease(c.config, list[0])
+	return c.GetRevision(name, 0)
 }
 
-// List lists releases
-func (c *releaseClient) List() ([]*Release, error) {
-	list, err := c.config.Releases.List(func(r *release.Release) bool {
-		return r.Namespace == c.config.Namespace()
-	})
+// GetRevision gets a specific revision of a release
+func (c *releaseClient) GetRevision(name string, revision int) (*Release, error) {
+	get := action.NewGet(c.config.Configuration)
+	get.Version = revision
+	release, err := get.Run(name)
 	if err != nil {
 		return nil, err
 	}
+	return getRelease(c.config, release)
+}
 
-	releases := make([]*Release, len(list))
-	for i, release := range list {
-		r, err := getRelease(c.config, release)
-		if err != nil {
-			return nil, err
-		}
-		releases[i] = r
+// List lists releases
+func (c *releaseClient) List() *ListRequest {
+	return &ListRequest{
+		client: c,
+		config: c.config,
 	}
-	return releases, nil
 }
 
 // Status gets the status of a release
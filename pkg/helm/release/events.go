@@ -0,0 +1,145 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"context"
+	"github.com/onosproject/helm-go/pkg/kubernetes/dynamic"
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	kubecorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// Events returns the events involving objects in the release, sorted by time
+func (r *Release) Events() ([]kubecorev1.Event, error) {
+	filter := newEventFilter(r)
+	events := make([]kubecorev1.Event, 0)
+	for _, namespace := range r.client.Namespaces() {
+		list, err := r.client.Clientset().CoreV1().Events(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range list.Items {
+			ok, err := filter.matches(event.InvolvedObject)
+			if err != nil {
+				return nil, err
+			} else if ok {
+				events = append(events, event)
+			}
+		}
+	}
+	resource.SortEvents(events)
+	return events, nil
+}
+
+// WatchEvents watches events involving objects in the release. Existing events are sent first, followed by
+// new events as they occur. The channel is closed once the context is cancelled.
+func (r *Release) WatchEvents(ctx context.Context) (<-chan kubecorev1.Event, error) {
+	events, err := resource.WatchNamespaces(ctx, r.client.Namespaces(), func(namespace, resourceVersion string) (watch.Interface, error) {
+		return r.client.Clientset().CoreV1().Events(namespace).Watch(metav1.ListOptions{
+			ResourceVersion: resourceVersion,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	filter := newEventFilter(r)
+	ch := make(chan kubecorev1.Event)
+	go func() {
+		defer close(ch)
+		for event := range events {
+			if event.Type == resource.EventDeleted {
+				continue
+			}
+			e, ok := event.Object.(*kubecorev1.Event)
+			if !ok {
+				continue
+			}
+			if ok, err := filter.matches(e.InvolvedObject); err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- *e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func newEventFilter(release *Release) *eventFilter {
+	return &eventFilter{
+		release: release,
+		objects: make(map[types.UID]bool),
+	}
+}
+
+// eventFilter filters events by whether the objects they involve are part of a release
+type eventFilter struct {
+	release *Release
+	objects map[types.UID]bool
+}
+
+// matches returns whether the given object is part of the release
+func (f *eventFilter) matches(object kubecorev1.ObjectReference) (bool, error) {
+	if ok, known := f.objects[object.UID]; known {
+		return ok, nil
+	}
+	ok, err := f.release.contains(object)
+	if err != nil {
+		return false, err
+	}
+	f.objects[object.UID] = ok
+	return ok, nil
+}
+
+// contains returns whether the given object is part of the release
+func (r *Release) contains(object kubecorev1.ObjectReference) (bool, error) {
+	for _, info := range r.resources {
+		kind := info.Object.GetObjectKind().GroupVersionKind()
+		if kind.Kind == object.Kind &&
+			kind.GroupVersion().String() == object.APIVersion &&
+			info.Namespace == object.Namespace &&
+			info.Name == object.Name {
+			return true, nil
+		}
+	}
+
+	// Objects of any kind, including custom resources, are read with the dynamic client and matched by the filter
+	groupVersion, err := schema.ParseGroupVersion(object.APIVersion)
+	if err != nil {
+		return false, err
+	}
+	current, err := dynamic.NewClient(r.clientFor(object.Namespace), r.filter, groupVersion.WithKind(object.Kind)).Get(object.Name)
+	if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return current.UID == object.UID, nil
+}
+
+// clientFor returns a client that reads the release's resources in the given namespace, so objects are looked up in
+// their own namespace rather than in each of the release's namespaces
+func (r *Release) clientFor(namespace string) resource.Client {
+	return resource.ForNamespace(r.client, namespace)
+}
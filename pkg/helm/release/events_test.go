@@ -0,0 +1,203 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/onosproject/helm-go/pkg/kubernetes"
+	"github.com/onosproject/helm-go/pkg/kubernetes/filter"
+	"github.com/stretchr/testify/assert"
+	helmkube "helm.sh/helm/v3/pkg/kube"
+	"io/ioutil"
+	kubecorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+// newEventServer returns a stand-in for the Kubernetes API server which serves a database owned by the onos-config
+// config map and an unrelated database
+func newEventServer(t *testing.T) *httptest.Server {
+	newDatabase := func(name string, owners ...metav1.OwnerReference) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "cloud.atomix.io/v1beta2",
+			"kind":       "Database",
+			"metadata": map[string]interface{}{
+				"namespace":       "test",
+				"name":            name,
+				"uid":             name,
+				"ownerReferences": owners,
+			},
+		}
+	}
+	responses := map[string]interface{}{
+		"/api": &metav1.APIVersions{
+			Versions: []string{"v1"},
+		},
+		"/apis": &metav1.APIGroupList{
+			Groups: []metav1.APIGroup{
+				{
+					Name:             "cloud.atomix.io",
+					Versions:         []metav1.GroupVersionForDiscovery{{GroupVersion: "cloud.atomix.io/v1beta2", Version: "v1beta2"}},
+					PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "cloud.atomix.io/v1beta2", Version: "v1beta2"},
+				},
+			},
+		},
+		"/api/v1": &metav1.APIResourceList{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: []string{"get", "list"}},
+			},
+		},
+		"/apis/cloud.atomix.io/v1beta2": &metav1.APIResourceList{
+			GroupVersion: "cloud.atomix.io/v1beta2",
+			APIResources: []metav1.APIResource{
+				{Name: "databases", Namespaced: true, Kind: "Database", Verbs: []string{"get", "list"}},
+			},
+		},
+		"/apis/cloud.atomix.io/v1beta2/namespaces/test/databases/raft": newDatabase("raft", metav1.OwnerReference{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Name:       "onos-config",
+			UID:        "1",
+		}),
+		"/apis/cloud.atomix.io/v1beta2/namespaces/test/databases/other": newDatabase("other"),
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		response, ok := responses[req.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+}
+
+// setKubeconfig points the client configuration at the given server until the returned function is called
+func setKubeconfig(t *testing.T, server *httptest.Server) func() {
+	file, err := ioutil.TempFile("", "kubeconfig")
+	assert.NoError(t, err)
+	_, err = fmt.Fprintf(file, `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+contexts:
+- name: test
+  context:
+    cluster: test
+current-context: test
+`, server.URL)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+	kubeconfig, set := os.LookupEnv("KUBECONFIG")
+	assert.NoError(t, os.Setenv("KUBECONFIG", file.Name()))
+	return func() {
+		if set {
+			_ = os.Setenv("KUBECONFIG", kubeconfig)
+		} else {
+			_ = os.Unsetenv("KUBECONFIG")
+		}
+		_ = os.Remove(file.Name())
+	}
+}
+
+func TestEventFilter(t *testing.T) {
+	release := &Release{
+		Namespace: "test",
+		Name:      "onos",
+		resources: helmkube.ResourceList{
+			&cliresource.Info{
+				Namespace: "test",
+				Name:      "onos-config",
+				Object: &kubecorev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "v1",
+						Kind:       "ConfigMap",
+					},
+				},
+			},
+		},
+	}
+
+	server := newEventServer(t)
+	defer server.Close()
+	defer setKubeconfig(t, server)()
+	parent, err := kubernetes.NewForNamespace("test")
+	assert.NoError(t, err)
+	release.filter = filter.Resources(parent, release.resources)
+	release.client, err = kubernetes.NewFiltered("test", release.filter)
+	assert.NoError(t, err)
+
+	eventFilter := newEventFilter(release)
+	ok, err := eventFilter.matches(kubecorev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Namespace:  "test",
+		Name:       "onos-config",
+		UID:        "1",
+	})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = eventFilter.matches(kubecorev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Namespace:  "test",
+		Name:       "other-config",
+		UID:        "2",
+	})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Objects of kinds the release client has no typed client for are read with the dynamic client
+	ok, err = eventFilter.matches(kubecorev1.ObjectReference{
+		APIVersion: "cloud.atomix.io/v1beta2",
+		Kind:       "Database",
+		Namespace:  "test",
+		Name:       "raft",
+		UID:        "raft",
+	})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = eventFilter.matches(kubecorev1.ObjectReference{
+		APIVersion: "cloud.atomix.io/v1beta2",
+		Kind:       "Database",
+		Namespace:  "test",
+		Name:       "other",
+		UID:        "other",
+	})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Kinds the cluster does not serve are not part of the release
+	ok, err = eventFilter.matches(kubecorev1.ObjectReference{
+		APIVersion: "cloud.atomix.io/v1beta2",
+		Kind:       "Partition",
+		Namespace:  "test",
+		Name:       "raft-1",
+		UID:        "3",
+	})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Len(t, eventFilter.objects, 5)
+}
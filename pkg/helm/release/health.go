@@ -0,0 +1,251 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"fmt"
+	appsv1 "github.com/onosproject/helm-go/pkg/kubernetes/apps/v1"
+	batchv1 "github.com/onosproject/helm-go/pkg/kubernetes/batch/v1"
+	corev1 "github.com/onosproject/helm-go/pkg/kubernetes/core/v1"
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	kubeappsv1 "k8s.io/api/apps/v1"
+	kubebatchv1 "k8s.io/api/batch/v1"
+	kubecorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// progressDeadlineExceededReason is the reason for a deployment's Progressing condition when its rollout has stalled
+const progressDeadlineExceededReason = "ProgressDeadlineExceeded"
+
+// Health is the health of a release or resource
+type Health string
+
+const (
+	// Healthy indicates that all resources are ready
+	Healthy Health = "healthy"
+	// Degraded indicates that some resources are not yet ready
+	Degraded Health = "degraded"
+	// Failed indicates that a resource has failed and will not recover on its own, e.g. a container is crash looping
+	// or cannot pull its image, a job has failed, or a deployment has exceeded its progress deadline
+	Failed Health = "failed"
+)
+
+// worse returns the worse of the two health states
+func (h Health) worse(other Health) Health {
+	if h == Failed || other == Failed {
+		return Failed
+	}
+	if h == Degraded || other == Degraded {
+		return Degraded
+	}
+	return Healthy
+}
+
+// HealthReport is a release health report
+type HealthReport struct {
+	// Health is the overall health of the release
+	Health Health
+	// Resources is the health of each workload in the release
+	Resources []ResourceHealth
+}
+
+// ResourceHealth is the health of a single resource
+type ResourceHealth struct {
+	Kind      resource.Kind
+	Namespace string
+	Name      string
+	Health    Health
+	// Ready is the number of ready replicas
+	Ready int32
+	// Desired is the number of desired replicas
+	Desired int32
+	// Restarts is the total number of container restarts
+	Restarts int32
+	// Reasons lists the reasons a resource is not healthy
+	Reasons []string
+}
+
+// Health checks the live state of the release's workloads and returns a health report
+func (r *Release) Health() (HealthReport, error) {
+	report := HealthReport{
+		Health:    Healthy,
+		Resources: make([]ResourceHealth, 0),
+	}
+	add := func(health ResourceHealth) {
+		report.Resources = append(report.Resources, health)
+		report.Health = report.Health.worse(health.Health)
+	}
+
+	deployments, err := r.client.AppsV1().Deployments().List()
+	if err != nil {
+		return HealthReport{}, err
+	}
+	for _, deployment := range deployments {
+		add(getDeploymentHealth(deployment.Object))
+	}
+
+	statefulSets, err := r.client.AppsV1().StatefulSets().List()
+	if err != nil {
+		return HealthReport{}, err
+	}
+	for _, statefulSet := range statefulSets {
+		add(getReplicasHealth(appsv1.StatefulSetKind, statefulSet.Object.ObjectMeta, getDesired(statefulSet.Object.Spec.Replicas), statefulSet.Object.Status.ReadyReplicas))
+	}
+
+	daemonSets, err := r.client.AppsV1().DaemonSets().List()
+	if err != nil {
+		return HealthReport{}, err
+	}
+	for _, daemonSet := range daemonSets {
+		add(getReplicasHealth(appsv1.DaemonSetKind, daemonSet.Object.ObjectMeta, daemonSet.Object.Status.DesiredNumberScheduled, daemonSet.Object.Status.NumberReady))
+	}
+
+	jobs, err := r.client.BatchV1().Jobs().List()
+	if err != nil {
+		return HealthReport{}, err
+	}
+	for _, job := range jobs {
+		add(getJobHealth(job.Object))
+	}
+
+	pods, err := r.client.CoreV1().Pods().List()
+	if err != nil {
+		return HealthReport{}, err
+	}
+	for _, pod := range pods {
+		add(getPodHealth(pod.Object))
+	}
+	return report, nil
+}
+
+func getDesired(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// getReplicasHealth returns the health of a controller from its ready and desired replicas. Replicas that are not
+// ready only degrade a controller, since they may be starting or rolling out; the controller's pods report failures.
+func getReplicasHealth(kind resource.Kind, meta metav1.ObjectMeta, desired int32, ready int32) ResourceHealth {
+	health := ResourceHealth{
+		Kind:      kind,
+		Namespace: meta.Namespace,
+		Name:      meta.Name,
+		Health:    Healthy,
+		Ready:     ready,
+		Desired:   desired,
+	}
+	if ready < desired {
+		health.Reasons = append(health.Reasons, fmt.Sprintf("%d/%d replicas ready", ready, desired))
+		health.Health = Degraded
+	}
+	return health
+}
+
+// getDeploymentHealth returns the health of a deployment, which has failed if its rollout exceeded its progress
+// deadline
+func getDeploymentHealth(deployment *kubeappsv1.Deployment) ResourceHealth {
+	health := getReplicasHealth(appsv1.DeploymentKind, deployment.ObjectMeta, getDesired(deployment.Spec.Replicas), deployment.Status.ReadyReplicas)
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == kubeappsv1.DeploymentProgressing &&
+			condition.Status == kubecorev1.ConditionFalse &&
+			condition.Reason == progressDeadlineExceededReason {
+			health.Health = Failed
+			health.Reasons = append(health.Reasons, fmt.Sprintf("progress deadline exceeded: %s", condition.Message))
+		}
+	}
+	return health
+}
+
+func getJobHealth(job *kubebatchv1.Job) ResourceHealth {
+	health := ResourceHealth{
+		Kind:      batchv1.JobKind,
+		Namespace: job.Namespace,
+		Name:      job.Name,
+		Health:    Healthy,
+		Ready:     job.Status.Succeeded,
+		Desired:   getDesired(job.Spec.Completions),
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == kubebatchv1.JobFailed && condition.Status == kubecorev1.ConditionTrue {
+			health.Health = Failed
+			health.Reasons = append(health.Reasons, fmt.Sprintf("job failed: %s", condition.Reason))
+		}
+	}
+	return health
+}
+
+func getPodHealth(pod *kubecorev1.Pod) ResourceHealth {
+	health := ResourceHealth{
+		Kind:      corev1.PodKind,
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		Health:    Healthy,
+		Desired:   int32(len(pod.Spec.Containers)),
+	}
+
+	// Init containers that crash or cannot pull their images block the pod from starting
+	for _, status := range pod.Status.InitContainerStatuses {
+		health.Restarts += status.RestartCount
+		if status.State.Waiting != nil && isFailedWaitingReason(status.State.Waiting.Reason) {
+			health.Health = Failed
+			health.Reasons = append(health.Reasons, fmt.Sprintf("init container %s: %s", status.Name, status.State.Waiting.Reason))
+		}
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		health.Restarts += status.RestartCount
+		if status.Ready {
+			health.Ready++
+		}
+		if status.State.Waiting != nil && isFailedWaitingReason(status.State.Waiting.Reason) {
+			health.Health = Failed
+			health.Reasons = append(health.Reasons, fmt.Sprintf("container %s: %s", status.Name, status.State.Waiting.Reason))
+		}
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == kubecorev1.PodScheduled &&
+			condition.Status == kubecorev1.ConditionFalse &&
+			condition.Reason == kubecorev1.PodReasonUnschedulable {
+			health.Health = Failed
+			health.Reasons = append(health.Reasons, fmt.Sprintf("unschedulable: %s", condition.Message))
+		}
+	}
+
+	switch pod.Status.Phase {
+	case kubecorev1.PodSucceeded:
+		return health
+	case kubecorev1.PodFailed:
+		health.Health = Failed
+		health.Reasons = append(health.Reasons, fmt.Sprintf("pod failed: %s", pod.Status.Reason))
+	default:
+		if health.Health == Healthy && health.Ready < health.Desired {
+			health.Health = Degraded
+			health.Reasons = append(health.Reasons, fmt.Sprintf("%d/%d containers ready", health.Ready, health.Desired))
+		}
+	}
+	return health
+}
+
+// isFailedWaitingReason returns whether a container waiting for the given reason will not start without intervention
+func isFailedWaitingReason(reason string) bool {
+	switch reason {
+	case "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "CreateContainerConfigError", "InvalidImageName":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestPodHealth(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "onos"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:         "onos",
+					Ready:        true,
+					RestartCount: 2,
+				},
+			},
+		},
+	}
+	health := getPodHealth(pod)
+	assert.Equal(t, Healthy, health.Health)
+	assert.Equal(t, int32(2), health.Restarts)
+
+	pod.Status.ContainerStatuses[0].Ready = false
+	health = getPodHealth(pod)
+	assert.Equal(t, Degraded, health.Health)
+
+	pod.Status.ContainerStatuses[0].State.Waiting = &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}
+	health = getPodHealth(pod)
+	assert.Equal(t, Failed, health.Health)
+	assert.Len(t, health.Reasons, 1)
+
+	pod = &corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.PodScheduled,
+					Status: corev1.ConditionFalse,
+					Reason: corev1.PodReasonUnschedulable,
+				},
+			},
+		},
+	}
+	assert.Equal(t, Failed, getPodHealth(pod).Health)
+
+	pod = &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init"}},
+			Containers:     []corev1.Container{{Name: "onos"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:         "init",
+					RestartCount: 3,
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+				},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "onos",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"},
+					},
+				},
+			},
+		},
+	}
+	health = getPodHealth(pod)
+	assert.Equal(t, Failed, health.Health)
+	assert.Equal(t, int32(3), health.Restarts)
+	assert.Equal(t, []string{"init container init: CrashLoopBackOff"}, health.Reasons)
+}
+
+func TestDeploymentHealth(t *testing.T) {
+	replicas := int32(2)
+	deployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas: 2,
+		},
+	}
+	assert.Equal(t, Healthy, getDeploymentHealth(deployment).Health)
+
+	// A deployment with no ready replicas may still be starting
+	deployment.Status.ReadyReplicas = 0
+	health := getDeploymentHealth(deployment)
+	assert.Equal(t, Degraded, health.Health)
+	assert.Equal(t, []string{"0/2 replicas ready"}, health.Reasons)
+
+	deployment.Status.Conditions = []appsv1.DeploymentCondition{
+		{
+			Type:    appsv1.DeploymentProgressing,
+			Status:  corev1.ConditionFalse,
+			Reason:  "ProgressDeadlineExceeded",
+			Message: "ReplicaSet \"onos-6c8d9f\" has timed out progressing.",
+		},
+	}
+	assert.Equal(t, Failed, getDeploymentHealth(deployment).Health)
+}
+
+func TestJobHealth(t *testing.T) {
+	job := &batchv1.Job{}
+	assert.Equal(t, Healthy, getJobHealth(job).Health)
+
+	job.Status.Conditions = []batchv1.JobCondition{
+		{
+			Type:   batchv1.JobFailed,
+			Status: corev1.ConditionTrue,
+			Reason: "BackoffLimitExceeded",
+		},
+	}
+	assert.Equal(t, Failed, getJobHealth(job).Health)
+}
+
+func TestHealthVerdict(t *testing.T) {
+	assert.Equal(t, Healthy, Healthy.worse(Healthy))
+	assert.Equal(t, Degraded, Healthy.worse(Degraded))
+	assert.Equal(t, Failed, Degraded.worse(Failed))
+	assert.Equal(t, Failed, Failed.worse(Healthy))
+}
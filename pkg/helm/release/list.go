@@ -0,0 +1,267 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"github.com/onosproject/helm-go/pkg/helm/config"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"path"
+	"regexp"
+)
+
+// SortBy is a release list sort order
+type SortBy int
+
+const (
+	// SortByName sorts releases by name
+	SortByName SortBy = iota
+	// SortByDate sorts releases by last deployment date, oldest first
+	SortByDate
+)
+
+// ListRequest is a release list request
+type ListRequest struct {
+	client        Client
+	config        *config.Config
+	allNamespaces bool
+	states        action.ListStates
+	filter        string
+	chart         string
+	chartVersion  string
+	sortBy        SortBy
+	reverse       bool
+	limit         int
+	offset        int
+}
+
+// AllNamespaces lists releases across all namespaces
+func (r *ListRequest) AllNamespaces() *ListRequest {
+	r.allNamespaces = true
+	return r
+}
+
+// All lists releases in any state
+func (r *ListRequest) All() *ListRequest {
+	r.states = action.ListAll
+	return r
+}
+
+// Deployed lists deployed releases
+func (r *ListRequest) Deployed() *ListRequest {
+	r.states |= action.ListDeployed
+	return r
+}
+
+// Failed lists failed releases
+func (r *ListRequest) Failed() *ListRequest {
+	r.states |= action.ListFailed
+	return r
+}
+
+// Pending lists releases with a pending install, upgrade or rollback
+func (r *ListRequest) Pending() *ListRequest {
+	r.states |= action.ListPendingInstall | action.ListPendingUpgrade | action.ListPendingRollback
+	return r
+}
+
+// Superseded lists superseded releases
+func (r *ListRequest) Superseded() *ListRequest {
+	r.states |= action.ListSuperseded
+	return r
+}
+
+// Uninstalled lists uninstalled releases
+func (r *ListRequest) Uninstalled() *ListRequest {
+	r.states |= action.ListUninstalled
+	return r
+}
+
+// Uninstalling lists releases that are being uninstalled
+func (r *ListRequest) Uninstalling() *ListRequest {
+	r.states |= action.ListUninstalling
+	return r
+}
+
+// Filter lists releases with names matching the given regular expression
+func (r *ListRequest) Filter(pattern string) *ListRequest {
+	r.filter = pattern
+	return r
+}
+
+// Chart lists releases of the given chart
+func (r *ListRequest) Chart(name string) *ListRequest {
+	r.chart = name
+	return r
+}
+
+// ChartVersion lists releases of the given chart version
+func (r *ListRequest) ChartVersion(version string) *ListRequest {
+	r.chartVersion = version
+	return r
+}
+
+// SortBy sets the order in which releases are listed
+func (r *ListRequest) SortBy(sortBy SortBy) *ListRequest {
+	r.sortBy = sortBy
+	return r
+}
+
+// Reverse reverses the sort order
+func (r *ListRequest) Reverse() *ListRequest {
+	r.reverse = true
+	return r
+}
+
+// Limit sets the maximum number of releases to list
+func (r *ListRequest) Limit(limit int) *ListRequest {
+	r.limit = limit
+	return r
+}
+
+// Offset sets the index of the first release to list
+func (r *ListRequest) Offset(offset int) *ListRequest {
+	r.offset = offset
+	return r
+}
+
+// Do lists the latest revision of each matching release, or every superseded revision if only superseded releases
+// are listed
+func (r *ListRequest) Do() ([]*Release, error) {
+	conf := r.config
+	if r.allNamespaces {
+		c, err := config.GetConfig("")
+		if err != nil {
+			return nil, err
+		}
+		conf = c
+	}
+
+	var filter *regexp.Regexp
+	if r.filter != "" {
+		f, err := regexp.Compile(r.filter)
+		if err != nil {
+			return nil, err
+		}
+		filter = f
+	}
+
+	list, err := conf.Releases.List(r.inNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	list = r.paginate(r.sort(r.selectReleases(list, filter)))
+	releases := make([]*Release, len(list))
+	for i, rel := range list {
+		release, err := getRelease(conf, rel)
+		if err != nil {
+			return nil, err
+		}
+		releases[i] = release
+	}
+	return releases, nil
+}
+
+// selectReleases returns the revisions in the given list that match the request. The state of a release is the state
+// of its latest revision, so the latest revision of each release is selected before the revisions are matched, unless
+// only superseded revisions are listed, in which case every superseded revision matches.
+func (r *ListRequest) selectReleases(list []*release.Release, filter *regexp.Regexp) []*release.Release {
+	if r.getStates() != action.ListSuperseded {
+		list = latest(list)
+	}
+	results := make([]*release.Release, 0, len(list))
+	for _, rel := range list {
+		if r.matches(rel, filter) {
+			results = append(results, rel)
+		}
+	}
+	return results
+}
+
+func (r *ListRequest) getStates() action.ListStates {
+	if r.states == 0 {
+		return action.ListDeployed | action.ListFailed
+	}
+	return r.states
+}
+
+func (r *ListRequest) inNamespace(rel *release.Release) bool {
+	return r.allNamespaces || rel.Namespace == r.client.Namespace()
+}
+
+func (r *ListRequest) matches(rel *release.Release, filter *regexp.Regexp) bool {
+	states := r.getStates()
+	if states&states.FromName(rel.Info.Status.String()) == 0 {
+		return false
+	}
+	if filter != nil && !filter.MatchString(rel.Name) {
+		return false
+	}
+	if r.chart != "" && (rel.Chart == nil || rel.Chart.Metadata == nil || rel.Chart.Metadata.Name != r.chart) {
+		return false
+	}
+	if r.chartVersion != "" && (rel.Chart == nil || rel.Chart.Metadata == nil || rel.Chart.Metadata.Version != r.chartVersion) {
+		return false
+	}
+	return true
+}
+
+func (r *ListRequest) sort(list []*release.Release) []*release.Release {
+	switch r.sortBy {
+	case SortByDate:
+		if r.reverse {
+			releaseutil.Reverse(list, releaseutil.SortByDate)
+		} else {
+			releaseutil.SortByDate(list)
+		}
+	default:
+		if r.reverse {
+			releaseutil.Reverse(list, releaseutil.SortByName)
+		} else {
+			releaseutil.SortByName(list)
+		}
+	}
+	return list
+}
+
+func (r *ListRequest) paginate(list []*release.Release) []*release.Release {
+	if r.offset >= len(list) {
+		return []*release.Release{}
+	}
+	last := len(list)
+	if r.limit > 0 && r.offset+r.limit < last {
+		last = r.offset + r.limit
+	}
+	return list[r.offset:last]
+}
+
+// latest returns the latest revision of each release in the list
+func latest(list []*release.Release) []*release.Release {
+	releases := make(map[string]*release.Release)
+	for _, rel := range list {
+		key := path.Join(rel.Namespace, rel.Name)
+		if current, ok := releases[key]; ok && current.Version > rel.Version {
+			continue
+		}
+		releases[key] = rel
+	}
+	results := make([]*release.Release, 0, len(releases))
+	for _, rel := range releases {
+		results = append(results, rel)
+	}
+	return results
+}
@@ -0,0 +1,169 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"github.com/onosproject/helm-go/pkg/helm/config"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/release"
+	helmtime "helm.sh/helm/v3/pkg/time"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func newTestRelease(name string, namespace string, version int, status release.Status, chartVersion string, deployed time.Time) *release.Release {
+	return &release.Release{
+		Name:      name,
+		Namespace: namespace,
+		Version:   version,
+		Info: &release.Info{
+			Status:       status,
+			LastDeployed: helmtime.Time{Time: deployed},
+		},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				Name:    "onos-classic",
+				Version: chartVersion,
+			},
+		},
+	}
+}
+
+func newTestListRequest() *ListRequest {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	settings := cli.New()
+	settings.AddFlags(fs)
+	_ = fs.Parse([]string{"--namespace", "default"})
+	conf := &config.Config{
+		Configuration: &action.Configuration{},
+		EnvSettings:   settings,
+	}
+	return NewClient(conf).List()
+}
+
+func TestListMatches(t *testing.T) {
+	now := time.Now()
+	deployed := newTestRelease("onos-1", "default", 1, release.StatusDeployed, "1.0.0", now)
+	failed := newTestRelease("onos-2", "default", 1, release.StatusFailed, "1.0.1", now)
+	superseded := newTestRelease("onos-1", "default", 0, release.StatusSuperseded, "1.0.0", now)
+	other := newTestRelease("onos-3", "other", 1, release.StatusDeployed, "1.0.0", now)
+
+	request := newTestListRequest()
+	assert.True(t, request.matches(deployed, nil))
+	assert.True(t, request.matches(failed, nil))
+	assert.False(t, request.matches(superseded, nil))
+	assert.True(t, request.inNamespace(deployed))
+	assert.False(t, request.inNamespace(other))
+
+	request = newTestListRequest().Failed()
+	assert.False(t, request.matches(deployed, nil))
+	assert.True(t, request.matches(failed, nil))
+
+	request = newTestListRequest().All().AllNamespaces()
+	assert.True(t, request.matches(superseded, nil))
+	assert.True(t, request.inNamespace(other))
+
+	request = newTestListRequest().ChartVersion("1.0.1")
+	assert.False(t, request.matches(deployed, nil))
+	assert.True(t, request.matches(failed, nil))
+
+	request = newTestListRequest().Chart("onos-classic")
+	assert.True(t, request.matches(deployed, nil))
+	request = newTestListRequest().Chart("onos-gui")
+	assert.False(t, request.matches(deployed, nil))
+
+	filter := regexp.MustCompile("-2$")
+	request = newTestListRequest()
+	assert.False(t, request.matches(deployed, filter))
+	assert.True(t, request.matches(failed, filter))
+}
+
+func TestListSelectReleases(t *testing.T) {
+	now := time.Now()
+	list := []*release.Release{
+		newTestRelease("onos", "default", 1, release.StatusFailed, "1.0.0", now.Add(-time.Hour)),
+		newTestRelease("onos", "default", 2, release.StatusDeployed, "1.0.1", now),
+		newTestRelease("atomix", "default", 1, release.StatusSuperseded, "1.0.0", now.Add(-time.Hour)),
+		newTestRelease("atomix", "default", 2, release.StatusSuperseded, "1.0.0", now.Add(-time.Minute)),
+		newTestRelease("atomix", "default", 3, release.StatusFailed, "1.0.0", now),
+	}
+
+	// A release whose latest revision is deployed is not failed, even if an earlier revision failed
+	releases := newTestListRequest().Failed().selectReleases(list, nil)
+	assert.Len(t, releases, 1)
+	assert.Equal(t, "atomix", releases[0].Name)
+	assert.Equal(t, 3, releases[0].Version)
+
+	releases = newTestListRequest().Deployed().selectReleases(list, nil)
+	assert.Len(t, releases, 1)
+	assert.Equal(t, "onos", releases[0].Name)
+	assert.Equal(t, 2, releases[0].Version)
+
+	// The chart version is matched against the latest revision
+	assert.Len(t, newTestListRequest().ChartVersion("1.0.0").Deployed().selectReleases(list, nil), 0)
+
+	// Every superseded revision is listed
+	releases = newTestListRequest().Superseded().selectReleases(list, nil)
+	assert.Len(t, releases, 2)
+	assert.Equal(t, "atomix", releases[0].Name)
+	assert.Equal(t, "atomix", releases[1].Name)
+}
+
+func TestListSortAndPaginate(t *testing.T) {
+	now := time.Now()
+	list := []*release.Release{
+		newTestRelease("b", "default", 1, release.StatusDeployed, "1.0.0", now.Add(-time.Minute)),
+		newTestRelease("a", "default", 1, release.StatusDeployed, "1.0.0", now),
+		newTestRelease("a", "default", 2, release.StatusDeployed, "1.0.0", now),
+		newTestRelease("c", "default", 1, release.StatusDeployed, "1.0.0", now.Add(-time.Hour)),
+	}
+
+	releases := latest(list)
+	assert.Len(t, releases, 3)
+
+	request := newTestListRequest()
+	releases = request.sort(releases)
+	assert.Equal(t, "a", releases[0].Name)
+	assert.Equal(t, 2, releases[0].Version)
+	assert.Equal(t, "b", releases[1].Name)
+	assert.Equal(t, "c", releases[2].Name)
+
+	request = newTestListRequest().SortBy(SortByDate)
+	releases = request.sort(releases)
+	assert.Equal(t, "c", releases[0].Name)
+	assert.Equal(t, "b", releases[1].Name)
+	assert.Equal(t, "a", releases[2].Name)
+
+	request = newTestListRequest().Reverse()
+	releases = request.sort(releases)
+	assert.Equal(t, "c", releases[0].Name)
+	assert.Equal(t, "a", releases[2].Name)
+
+	request = newTestListRequest().Offset(1).Limit(1)
+	page := request.paginate(releases)
+	assert.Len(t, page, 1)
+	assert.Equal(t, "b", page[0].Name)
+
+	request = newTestListRequest().Offset(2).Limit(5)
+	assert.Len(t, request.paginate(releases), 1)
+
+	request = newTestListRequest().Offset(3)
+	assert.Len(t, request.paginate(releases), 0)
+}
@@ -0,0 +1,154 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"bufio"
+	"fmt"
+	corev1 "github.com/onosproject/helm-go/pkg/kubernetes/core/v1"
+	"io"
+	kubecorev1 "k8s.io/api/core/v1"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Logs returns a stream of the logs of every init and app container of every pod in the release. Each line is
+// prefixed with the name of the pod and container from which it was read. Containers whose logs cannot be read, e.g.
+// because the pod is still pending or the previous logs of a container that never restarted were requested, and
+// streams that fail while being read are reported as an error line in the stream rather than failing the request.
+func (r *Release) Logs(options corev1.LogOptions) (io.ReadCloser, error) {
+	pods, err := r.client.CoreV1().Pods().List()
+	if err != nil {
+		return nil, err
+	}
+
+	streams := make(map[string]io.ReadCloser)
+	failures := make(map[string]error)
+	for _, pod := range pods {
+		containers := append(append([]kubecorev1.Container{}, pod.Object.Spec.InitContainers...), pod.Object.Spec.Containers...)
+		for _, container := range containers {
+			if options.Container != "" && options.Container != container.Name {
+				continue
+			}
+			containerOptions := options
+			containerOptions.Container = container.Name
+			prefix := fmt.Sprintf("[%s/%s] ", pod.Name, container.Name)
+			stream, err := pod.Logs(containerOptions)
+			if err != nil {
+				failures[prefix] = err
+			} else {
+				streams[prefix] = stream
+			}
+		}
+	}
+	return newLogMultiplexer(streams, failures), nil
+}
+
+// newLogMultiplexer returns a reader that merges the lines of the given streams, prefixing each line with its key.
+// The given failures are written first as error lines prefixed with their keys.
+func newLogMultiplexer(streams map[string]io.ReadCloser, failures map[string]error) io.ReadCloser {
+	reader, writer := io.Pipe()
+	multiplexer := &logMultiplexer{
+		reader:  reader,
+		streams: make([]io.ReadCloser, 0, len(streams)),
+	}
+
+	prefixes := make([]string, 0, len(streams))
+	for prefix := range streams {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	failed := make([]string, 0, len(failures))
+	for prefix := range failures {
+		failed = append(failed, prefix)
+	}
+	sort.Strings(failed)
+
+	wg := &sync.WaitGroup{}
+	mu := &sync.Mutex{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for _, prefix := range failed {
+			mu.Lock()
+			_, err := writer.Write([]byte(formatLogError(prefix, failures[prefix])))
+			mu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}()
+	for _, prefix := range prefixes {
+		stream := streams[prefix]
+		multiplexer.streams = append(multiplexer.streams, stream)
+		wg.Add(1)
+		go func(prefix string, stream io.Reader) {
+			defer wg.Done()
+			lines := bufio.NewReader(stream)
+			for {
+				line, err := lines.ReadString('\n')
+				if line != "" {
+					if !strings.HasSuffix(line, "\n") {
+						line += "\n"
+					}
+					mu.Lock()
+					_, writeErr := writer.Write([]byte(prefix + line))
+					mu.Unlock()
+					if writeErr != nil {
+						return
+					}
+				}
+				if err != nil {
+					if err != io.EOF {
+						mu.Lock()
+						_, _ = writer.Write([]byte(formatLogError(prefix, err)))
+						mu.Unlock()
+					}
+					return
+				}
+			}
+		}(prefix, stream)
+	}
+
+	go func() {
+		wg.Wait()
+		_ = writer.Close()
+	}()
+	return multiplexer
+}
+
+// formatLogError formats an error reading the log stream with the given prefix as a log line
+func formatLogError(prefix string, err error) string {
+	return fmt.Sprintf("%serror: %s\n", prefix, strings.TrimSpace(err.Error()))
+}
+
+// logMultiplexer is a reader over multiplexed log streams
+type logMultiplexer struct {
+	reader  *io.PipeReader
+	streams []io.ReadCloser
+}
+
+func (m *logMultiplexer) Read(p []byte) (int, error) {
+	return m.reader.Read(p)
+}
+
+func (m *logMultiplexer) Close() error {
+	for _, stream := range m.streams {
+		_ = stream.Close()
+	}
+	return m.reader.Close()
+}
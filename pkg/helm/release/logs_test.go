@@ -0,0 +1,74 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestLogMultiplexer(t *testing.T) {
+	streams := map[string]io.ReadCloser{
+		"[onos-0/onos] ":   ioutil.NopCloser(strings.NewReader("foo\nbar\n")),
+		"[onos-1/onos] ":   ioutil.NopCloser(strings.NewReader("baz")),
+		"[onos-1/atomix] ": ioutil.NopCloser(strings.NewReader("")),
+	}
+	reader := newLogMultiplexer(streams, nil)
+	bytes, err := ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.NoError(t, reader.Close())
+
+	lines := strings.Split(strings.TrimSuffix(string(bytes), "\n"), "\n")
+	sort.Strings(lines)
+	assert.Equal(t, []string{
+		"[onos-0/onos] bar",
+		"[onos-0/onos] foo",
+		"[onos-1/onos] baz",
+	}, lines)
+}
+
+type failingReader struct{}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestLogMultiplexerErrors(t *testing.T) {
+	streams := map[string]io.ReadCloser{
+		"[onos-0/onos] ": ioutil.NopCloser(io.MultiReader(strings.NewReader("foo\n"), &failingReader{})),
+	}
+	failures := map[string]error{
+		"[onos-1/onos] ":      errors.New("container \"onos\" in pod \"onos-1\" is waiting to start: ContainerCreating"),
+		"[onos-0/init-onos] ": errors.New("previous terminated container \"init-onos\" not found"),
+	}
+	reader := newLogMultiplexer(streams, failures)
+	bytes, err := ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.NoError(t, reader.Close())
+
+	lines := strings.Split(strings.TrimSuffix(string(bytes), "\n"), "\n")
+	sort.Strings(lines)
+	assert.Equal(t, []string{
+		"[onos-0/init-onos] error: previous terminated container \"init-onos\" not found",
+		"[onos-0/onos] error: connection reset",
+		"[onos-0/onos] foo",
+		"[onos-1/onos] error: container \"onos\" in pod \"onos-1\" is waiting to start: ContainerCreating",
+	}, lines)
+}
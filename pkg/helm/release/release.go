@@ -16,10 +16,15 @@ package release
 
 import (
 	"bytes"
+	"context"
 	"github.com/onosproject/helm-go/pkg/helm/config"
 	"github.com/onosproject/helm-go/pkg/helm/values"
 	"github.com/onosproject/helm-go/pkg/kubernetes"
 	"github.com/onosproject/helm-go/pkg/kubernetes/filter"
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	helmkube "helm.sh/helm/v3/pkg/kube"
 	"helm.sh/helm/v3/pkg/release"
 	"time"
 )
@@ -50,6 +55,8 @@ const (
 // StatusReport is Helm release status report
 type StatusReport struct {
 	Status        Status
+	Revision      int
+	Description   string
 	FirstDeployed time.Time
 	LastDeployed  time.Time
 }
@@ -57,24 +64,83 @@ type StatusReport struct {
 // Release is a Helm release
 type Release struct {
 	StatusReport
-	Namespace string
-	Name      string
-	values    *values.ImmutableValues
-	client    kubernetes.Client
+	Namespace    string
+	Name         string
+	ChartName    string
+	ChartVersion string
+	AppVersion   string
+	Notes        string
+	Chart        *chart.Metadata
+	userValues   *values.ImmutableValues
+	values       *values.ImmutableValues
+	resources    helmkube.ResourceList
+	filter       resource.Filter
+	owners       *filter.OwnerCache
+	client       kubernetes.Client
 }
 
-// Values returns the release values
+// Values returns the computed release values
 func (r *Release) Values() *values.ImmutableValues {
 	return r.values
 }
 
-// Client returns the release client
+// UserValues returns the values supplied by the user when the release was installed or upgraded
+func (r *Release) UserValues() *values.ImmutableValues {
+	return r.userValues
+}
+
+// ComputedValues returns the effective release values, coalesced with the chart and sub-chart defaults
+func (r *Release) ComputedValues() *values.ImmutableValues {
+	return r.values
+}
+
+// Client returns the release client. The client reads namespaced resources from the release namespace and from every
+// other namespace in the release manifest, and reads cluster scoped resources cluster-wide.
 func (r *Release) Client() kubernetes.Client {
 	return r.client
 }
 
+// Filter returns the filter that selects the release's resources. Clients generated for custom resources can use the
+// filter to read the resources of the release, e.g. atomix.NewFiltered(release.Namespace, release.Filter()), and it
+// can be combined with other filters, e.g. resource.And(release.Filter(), resource.LabelSelector("role=leader"))
+func (r *Release) Filter() resource.Filter {
+	return r.filter
+}
+
+// ClientWithHelmLabels returns a release client that also reads the resources labeled as managed by Helm and
+// annotated with the release name and namespace, e.g. resources created by hooks. Helm 3.2 and later label and
+// annotate the resources it installs.
+func (r *Release) ClientWithHelmLabels() (kubernetes.Client, error) {
+	parent, err := kubernetes.NewForNamespace(r.Namespace, kubernetes.WithClientsets(resource.GetClientsets(r.client)))
+	if err != nil {
+		return nil, err
+	}
+	// Owners are not shared with the release's other clients since more owners are part of the release in this mode
+	releaseFilter := filter.Resources(parent, r.resources, filter.WithHelmLabels(r.Name, r.Namespace))
+	return kubernetes.NewFiltered(r.Namespace, releaseFilter,
+		kubernetes.WithClientsets(resource.GetClientsets(parent)),
+		kubernetes.WithNamespaces(getNamespaces(r.resources)...))
+}
+
+// CachedClient returns a release client that reads resources from shared informers rather than querying the
+// API server on every request. The informers are stopped when the given context is cancelled.
+func (r *Release) CachedClient(ctx context.Context) (kubernetes.Client, error) {
+	cache := resource.NewCache(ctx)
+	parent, err := kubernetes.NewForNamespace(r.Namespace,
+		kubernetes.WithClientsets(resource.GetClientsets(r.client)),
+		kubernetes.WithCache(cache))
+	if err != nil {
+		return nil, err
+	}
+	releaseFilter := filter.Resources(parent, r.resources, filter.WithOwnerCache(r.owners))
+	return kubernetes.NewFiltered(r.Namespace, releaseFilter,
+		kubernetes.WithClientsets(resource.GetClientsets(parent)),
+		kubernetes.WithCache(cache),
+		kubernetes.WithNamespaces(getNamespaces(r.resources)...))
+}
+
 func getRelease(config *config.Config, release *release.Release) (*Release, error) {
-	resources, err := config.KubeClient.Build(bytes.NewBufferString(release.Manifest), true)
+	resources, err := buildManifest(config, release)
 	if err != nil {
 		return nil, err
 	}
@@ -84,21 +150,82 @@ func getRelease(config *config.Config, release *release.Release) (*Release, erro
 		return nil, err
 	}
 
-	client, err := kubernetes.NewFiltered(release.Namespace, filter.Resources(parent, resources))
+	// Owners are shared by the release's clients so each owner is resolved once per filter.OwnerCacheTTL
+	owners := filter.NewOwnerCache()
+	releaseFilter := filter.Resources(parent, resources, filter.WithOwnerCache(owners))
+	client, err := kubernetes.NewFiltered(release.Namespace, releaseFilter,
+		kubernetes.WithClientsets(resource.GetClientsets(parent)),
+		kubernetes.WithNamespaces(getNamespaces(resources)...))
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := release.Chart.Metadata
+	if metadata == nil {
+		metadata = &chart.Metadata{}
+	}
+
+	userValues, computedValues, err := getReleaseValues(release)
 	if err != nil {
 		return nil, err
 	}
 
-	values := values.New(release.Chart.Values).Override(values.New(release.Config))
 	return &Release{
 		StatusReport: StatusReport{
 			Status:        Status(release.Info.Status),
+			Revision:      release.Version,
+			Description:   release.Info.Description,
 			FirstDeployed: release.Info.FirstDeployed.Time,
 			LastDeployed:  release.Info.LastDeployed.Time,
 		},
-		Namespace: release.Namespace,
-		Name:      release.Name,
-		values:    values.Immutable(),
-		client:    client,
+		Namespace:    release.Namespace,
+		Name:         release.Name,
+		ChartName:    metadata.Name,
+		ChartVersion: metadata.Version,
+		AppVersion:   metadata.AppVersion,
+		Notes:        release.Info.Notes,
+		Chart:        metadata,
+		userValues:   userValues,
+		values:       computedValues,
+		resources:    resources,
+		filter:       releaseFilter,
+		owners:       owners,
+		client:       client,
 	}, nil
 }
+
+// buildManifest builds the resources in the manifest of the given release. Namespaced resources that do not set a
+// namespace default to the release namespace rather than the namespace of the configured kube client, which may
+// differ when releases are listed across namespaces.
+func buildManifest(config *config.Config, release *release.Release) (helmkube.ResourceList, error) {
+	kubeClient := config.KubeClient
+	if client, ok := kubeClient.(*helmkube.Client); ok {
+		releaseClient := *client
+		releaseClient.Namespace = release.Namespace
+		kubeClient = &releaseClient
+	}
+	return kubeClient.Build(bytes.NewBufferString(release.Manifest), true)
+}
+
+// getNamespaces returns the namespaces of the namespaced resources in the given release manifest
+func getNamespaces(resources helmkube.ResourceList) []string {
+	namespaces := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, resource := range resources {
+		if resource.Namespace != "" && !seen[resource.Namespace] {
+			seen[resource.Namespace] = true
+			namespaces = append(namespaces, resource.Namespace)
+		}
+	}
+	return namespaces
+}
+
+// getReleaseValues returns the user-supplied and computed values for the given release
+func getReleaseValues(release *release.Release) (*values.ImmutableValues, *values.ImmutableValues, error) {
+	userValues := values.New(release.Config)
+	computedValues, err := chartutil.CoalesceValues(release.Chart, userValues.Values())
+	if err != nil {
+		return nil, nil, err
+	}
+	return userValues.Immutable(), values.New(computedValues).Immutable(), nil
+}
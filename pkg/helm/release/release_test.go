@@ -13,3 +13,187 @@
 // limitations under the License.
 
 package release
+
+import (
+	"encoding/json"
+	"github.com/onosproject/helm-go/pkg/helm/config"
+	"github.com/onosproject/helm-go/pkg/kubernetes"
+	"github.com/onosproject/helm-go/pkg/kubernetes/filter"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	helmkube "helm.sh/helm/v3/pkg/kube"
+	"helm.sh/helm/v3/pkg/release"
+	kubecorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReleaseValues(t *testing.T) {
+	subchart := &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name: "atomix",
+		},
+		Values: map[string]interface{}{
+			"replicas": 1,
+			"image":    "atomix/raft",
+		},
+	}
+	parent := &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name: "onos",
+		},
+		Values: map[string]interface{}{
+			"replicas": 1,
+			"heap":     "2G",
+			"global": map[string]interface{}{
+				"storage": "raft",
+			},
+		},
+	}
+	parent.AddDependency(subchart)
+
+	rel := &release.Release{
+		Chart: parent,
+		Config: map[string]interface{}{
+			"replicas": 3,
+			"heap":     nil,
+			"atomix": map[string]interface{}{
+				"replicas": 3,
+			},
+		},
+	}
+
+	userValues, computedValues, err := getReleaseValues(rel)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, userValues.Get("replicas"))
+	assert.Equal(t, 3, userValues.Get("atomix.replicas"))
+	assert.Nil(t, userValues.Get("atomix.image"))
+
+	assert.Equal(t, 3, computedValues.Get("replicas"))
+	assert.Nil(t, computedValues.Get("heap"))
+	assert.Equal(t, 3, computedValues.Get("atomix.replicas"))
+	assert.Equal(t, "atomix/raft", computedValues.Get("atomix.image"))
+	assert.Equal(t, "raft", computedValues.Get("atomix.global.storage"))
+
+	_, hasHeap := computedValues.Values()["heap"]
+	assert.False(t, hasHeap)
+	assert.Equal(t, 1, parent.Values["replicas"])
+	assert.Equal(t, "2G", parent.Values["heap"])
+}
+
+func TestReleaseNamespaces(t *testing.T) {
+	resources := helmkube.ResourceList{
+		&cliresource.Info{Namespace: "onos", Name: "onos-config"},
+		&cliresource.Info{Namespace: "kube-system", Name: "onos-webhook"},
+		&cliresource.Info{Namespace: "onos", Name: "onos-topo"},
+		&cliresource.Info{Name: "onos-cluster-role"},
+	}
+	assert.Equal(t, []string{"onos", "kube-system"}, getNamespaces(resources))
+}
+
+func TestBuildManifest(t *testing.T) {
+	factory := cmdtesting.NewTestFactory().WithNamespace("default")
+	defer factory.Cleanup()
+	conf := &config.Config{
+		Configuration: &action.Configuration{
+			KubeClient: &helmkube.Client{Factory: factory},
+		},
+	}
+	rel := &release.Release{
+		Name:      "onos",
+		Namespace: "onos",
+		Manifest: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: onos-config
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: onos-webhook
+  namespace: kube-system
+`,
+	}
+	resources, err := buildManifest(conf, rel)
+	assert.NoError(t, err)
+	assert.Len(t, resources, 2)
+	assert.Equal(t, "onos", resources[0].Namespace)
+	assert.Equal(t, "kube-system", resources[1].Namespace)
+	assert.Equal(t, []string{"onos", "kube-system"}, getNamespaces(resources))
+}
+
+func TestReleaseClientWithHelmLabels(t *testing.T) {
+	newConfigMap := func(name string, labels, annotations map[string]string) *kubecorev1.ConfigMap {
+		return &kubecorev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "test",
+				Name:        name,
+				UID:         types.UID(name),
+				Labels:      labels,
+				Annotations: annotations,
+			},
+		}
+	}
+	responses := map[string]interface{}{
+		"/api/v1/namespaces/test/configmaps/onos-config": newConfigMap("onos-config", nil, nil),
+		"/api/v1/namespaces/test/configmaps/onos-hook": newConfigMap("onos-hook",
+			map[string]string{"app.kubernetes.io/managed-by": "Helm"},
+			map[string]string{"meta.helm.sh/release-name": "onos", "meta.helm.sh/release-namespace": "test"}),
+		"/api/v1/namespaces/test/configmaps/other-config": newConfigMap("other-config", nil, nil),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		response, ok := responses[req.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+	defer setKubeconfig(t, server)()
+
+	release := &Release{
+		Namespace: "test",
+		Name:      "onos",
+		resources: helmkube.ResourceList{
+			&cliresource.Info{
+				Namespace: "test",
+				Name:      "onos-config",
+				Object:    newConfigMap("onos-config", nil, nil),
+			},
+		},
+	}
+	parent, err := kubernetes.NewForNamespace("test")
+	assert.NoError(t, err)
+	release.filter = filter.Resources(parent, release.resources)
+	release.client, err = kubernetes.NewFiltered("test", release.filter)
+	assert.NoError(t, err)
+
+	// Resources that are not in the release manifest are only read in Helm label mode
+	_, err = release.Client().CoreV1().ConfigMaps().Get("onos-hook")
+	assert.True(t, errors.IsNotFound(err))
+
+	client, err := release.ClientWithHelmLabels()
+	assert.NoError(t, err)
+	configMap, err := client.CoreV1().ConfigMaps().Get("onos-config")
+	assert.NoError(t, err)
+	assert.Equal(t, "onos-config", configMap.Name)
+	configMap, err = client.CoreV1().ConfigMaps().Get("onos-hook")
+	assert.NoError(t, err)
+	assert.Equal(t, "onos-hook", configMap.Name)
+	_, err = client.CoreV1().ConfigMaps().Get("other-config")
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -0,0 +1,320 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/onosproject/helm-go/pkg/kubernetes/dynamic"
+	"github.com/onosproject/helm-go/pkg/kubernetes/filter"
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	"io"
+	kubeappsv1 "k8s.io/api/apps/v1"
+	kubebatchv1 "k8s.io/api/batch/v1"
+	kubecorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Tree is the ownership tree of a release's objects
+type Tree struct {
+	// Roots are the objects that are not owned by any other object in the release
+	Roots []*TreeNode
+}
+
+// TreeNode is an object in a release tree
+type TreeNode struct {
+	Kind      resource.Kind
+	Namespace string
+	Name      string
+	UID       types.UID
+	// Health is the health of the object, or empty if the health of the kind is not checked
+	Health Health
+	// Reasons lists the reasons the object is not healthy
+	Reasons []string
+	// Object is the live state of the object
+	Object *unstructured.Unstructured
+	// Children are the objects owned by the object
+	Children []*TreeNode
+	parent   *TreeNode
+}
+
+// Tree returns the tree of objects in the release, with each object's descendants nested beneath it, e.g.
+// Deployment -> ReplicaSet -> Pod. Objects are nested by their owner references, and by the naming and labeling
+// conventions Kubernetes uses for objects that are created without them, e.g. Service -> Endpoints and
+// StatefulSet -> PersistentVolumeClaim.
+func (r *Release) Tree() (*Tree, error) {
+	objects, err := r.client.All()
+	if err != nil {
+		return nil, err
+	}
+	return newTree(objects)
+}
+
+// treeKey is a key for looking up objects in a tree by name
+type treeKey struct {
+	group     string
+	kind      string
+	namespace string
+	name      string
+}
+
+// newTree builds a tree from the given objects
+func newTree(objects []*dynamic.Object) (*Tree, error) {
+	nodes := make([]*TreeNode, 0, len(objects))
+	uids := make(map[types.UID]*TreeNode)
+	names := make(map[treeKey]*TreeNode)
+	for _, object := range objects {
+		node, err := newTreeNode(object)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+		uids[node.UID] = node
+		names[treeKey{node.Kind.Group, node.Kind.Kind, node.Namespace, node.Name}] = node
+	}
+
+	tree := &Tree{}
+	for _, node := range nodes {
+		parent := getTreeParent(node, uids, names, nodes)
+		if parent == nil || parent.isDescendantOf(node) {
+			tree.Roots = append(tree.Roots, node)
+		} else {
+			node.parent = parent
+			parent.Children = append(parent.Children, node)
+		}
+	}
+
+	sortTreeNodes(tree.Roots)
+	for _, node := range nodes {
+		sortTreeNodes(node.Children)
+	}
+	return tree, nil
+}
+
+func newTreeNode(object *dynamic.Object) (*TreeNode, error) {
+	node := &TreeNode{
+		Kind:      object.Kind,
+		Namespace: object.Namespace,
+		Name:      object.Name,
+		UID:       object.UID,
+		Object:    object.Object,
+	}
+	health, err := getObjectHealth(object.Kind, object.Object)
+	if err != nil {
+		return nil, err
+	}
+	if health != nil {
+		node.Health = health.Health
+		node.Reasons = health.Reasons
+	}
+	return node, nil
+}
+
+// isDescendantOf returns whether the node is the given node or one of its descendants
+func (n *TreeNode) isDescendantOf(node *TreeNode) bool {
+	for parent := n; parent != nil; parent = parent.parent {
+		if parent == node {
+			return true
+		}
+	}
+	return false
+}
+
+// getTreeParent returns the parent of the given node, preferring the controller of the object, then any other owner,
+// and finally the conventional parent of objects created without owner references
+func getTreeParent(node *TreeNode, uids map[types.UID]*TreeNode, names map[treeKey]*TreeNode, nodes []*TreeNode) *TreeNode {
+	if controller := metav1.GetControllerOf(node.Object); controller != nil {
+		if parent, ok := uids[controller.UID]; ok {
+			return parent
+		}
+	}
+	for _, owner := range node.Object.GetOwnerReferences() {
+		if parent, ok := uids[owner.UID]; ok {
+			return parent
+		}
+	}
+
+	if node.Kind.Group == "" && node.Kind.Kind == "PersistentVolumeClaim" {
+		for _, statefulSet := range nodes {
+			if statefulSet.Kind.Group == "apps" && statefulSet.Kind.Kind == "StatefulSet" &&
+				statefulSet.Namespace == node.Namespace && filter.IsVolumeClaimOf(node.Name, statefulSet.Object) {
+				return statefulSet
+			}
+		}
+		return nil
+	}
+
+	kind := metav1.GroupVersionKind{Group: node.Kind.Group, Version: node.Kind.Version, Kind: node.Kind.Kind}
+	meta := metav1.ObjectMeta{Namespace: node.Namespace, Name: node.Name, Labels: node.Object.GetLabels()}
+	related, ok := filter.GetRelated(kind, meta)
+	if !ok {
+		return nil
+	}
+	if related.Name != "" {
+		return names[treeKey{related.Kind.Group, related.Kind.Kind, node.Namespace, related.Name}]
+	}
+	selector := related.Labels.AsSelector()
+	for _, parent := range nodes {
+		if parent.Kind.Group == related.Kind.Group && parent.Kind.Kind == related.Kind.Kind &&
+			parent.Namespace == node.Namespace && selector.Matches(labels.Set(parent.Object.GetLabels())) {
+			return parent
+		}
+	}
+	return nil
+}
+
+func sortTreeNodes(nodes []*TreeNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Kind.Kind != nodes[j].Kind.Kind {
+			return nodes[i].Kind.Kind < nodes[j].Kind.Kind
+		}
+		if nodes[i].Namespace != nodes[j].Namespace {
+			return nodes[i].Namespace < nodes[j].Namespace
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+}
+
+// getObjectHealth returns the health of the given object, or nil if the health of the kind is not checked
+func getObjectHealth(kind resource.Kind, object *unstructured.Unstructured) (*ResourceHealth, error) {
+	var health ResourceHealth
+	switch {
+	case kind.Group == "apps" && kind.Kind == "Deployment":
+		deployment := &kubeappsv1.Deployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, deployment); err != nil {
+			return nil, err
+		}
+		health = getDeploymentHealth(deployment)
+	case kind.Group == "apps" && kind.Kind == "StatefulSet":
+		statefulSet := &kubeappsv1.StatefulSet{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, statefulSet); err != nil {
+			return nil, err
+		}
+		health = getReplicasHealth(kind, statefulSet.ObjectMeta, getDesired(statefulSet.Spec.Replicas), statefulSet.Status.ReadyReplicas)
+	case kind.Group == "apps" && kind.Kind == "ReplicaSet":
+		replicaSet := &kubeappsv1.ReplicaSet{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, replicaSet); err != nil {
+			return nil, err
+		}
+		health = getReplicasHealth(kind, replicaSet.ObjectMeta, getDesired(replicaSet.Spec.Replicas), replicaSet.Status.ReadyReplicas)
+	case kind.Group == "apps" && kind.Kind == "DaemonSet":
+		daemonSet := &kubeappsv1.DaemonSet{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, daemonSet); err != nil {
+			return nil, err
+		}
+		health = getReplicasHealth(kind, daemonSet.ObjectMeta, daemonSet.Status.DesiredNumberScheduled, daemonSet.Status.NumberReady)
+	case kind.Group == "batch" && kind.Kind == "Job":
+		job := &kubebatchv1.Job{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, job); err != nil {
+			return nil, err
+		}
+		health = getJobHealth(job)
+	case kind.Group == "" && kind.Kind == "Pod":
+		pod := &kubecorev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, pod); err != nil {
+			return nil, err
+		}
+		health = getPodHealth(pod)
+	case kind.Group == "" && kind.Kind == "PersistentVolumeClaim":
+		claim := &kubecorev1.PersistentVolumeClaim{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, claim); err != nil {
+			return nil, err
+		}
+		health = getVolumeClaimHealth(claim)
+	default:
+		return nil, nil
+	}
+	return &health, nil
+}
+
+func getVolumeClaimHealth(claim *kubecorev1.PersistentVolumeClaim) ResourceHealth {
+	health := ResourceHealth{
+		Kind:      resource.Kind{Version: "v1", Kind: "PersistentVolumeClaim", Scoped: true},
+		Namespace: claim.Namespace,
+		Name:      claim.Name,
+		Health:    Healthy,
+	}
+	switch claim.Status.Phase {
+	case kubecorev1.ClaimPending:
+		health.Health = Degraded
+		health.Reasons = append(health.Reasons, "claim pending")
+	case kubecorev1.ClaimLost:
+		health.Health = Failed
+		health.Reasons = append(health.Reasons, "claim lost")
+	}
+	return health
+}
+
+// String renders the tree as text
+func (t *Tree) String() string {
+	buf := &bytes.Buffer{}
+	_ = t.Write(buf)
+	return buf.String()
+}
+
+// Write renders the tree to the given writer as a table in the style of kubectl tree:
+//
+//	NAMESPACE  NAME                           HEALTH    REASONS
+//	default    Deployment/web                 degraded  1/2 replicas ready
+//	default    └─ReplicaSet/web-6c8d9f        degraded  1/2 replicas ready
+//	default      ├─Pod/web-6c8d9f-4xkzp       healthy
+//	default      └─Pod/web-6c8d9f-8j2lq       failed    container web: CrashLoopBackOff
+func (t *Tree) Write(w io.Writer) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(writer, "NAMESPACE\tNAME\tHEALTH\tREASONS"); err != nil {
+		return err
+	}
+	for _, root := range t.Roots {
+		if err := writeTreeNode(writer, root, "", ""); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// writeTreeNode writes the given node and its descendants. The prefix is written before the node's name, and the
+// indent before the names of its children.
+func writeTreeNode(w io.Writer, node *TreeNode, prefix, indent string) error {
+	namespace := node.Namespace
+	if namespace == "" {
+		namespace = "-"
+	}
+	health := string(node.Health)
+	if health == "" {
+		health = "-"
+	}
+	if _, err := fmt.Fprintf(w, "%s\t%s%s/%s\t%s\t%s\n", namespace, prefix, node.Kind.Kind, node.Name, health, strings.Join(node.Reasons, ", ")); err != nil {
+		return err
+	}
+	for i, child := range node.Children {
+		if i == len(node.Children)-1 {
+			if err := writeTreeNode(w, child, indent+"└─", indent+"  "); err != nil {
+				return err
+			}
+		} else {
+			if err := writeTreeNode(w, child, indent+"├─", indent+"│ "); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"github.com/onosproject/helm-go/pkg/kubernetes/dynamic"
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"strings"
+	"testing"
+)
+
+func newTreeObject(kind resource.Kind, name string, labels map[string]interface{}, owner string, fields map[string]interface{}) *dynamic.Object {
+	object := map[string]interface{}{
+		"apiVersion": kind.Version,
+		"kind":       kind.Kind,
+		"metadata": map[string]interface{}{
+			"namespace": "default",
+			"name":      name,
+			"uid":       name + "-uid",
+			"labels":    labels,
+		},
+	}
+	if kind.Group != "" {
+		object["apiVersion"] = kind.Group + "/" + kind.Version
+	}
+	if owner != "" {
+		controller := true
+		object["metadata"].(map[string]interface{})["ownerReferences"] = []interface{}{
+			map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Owner",
+				"name":       owner,
+				"uid":        owner + "-uid",
+				"controller": controller,
+			},
+		}
+	}
+	for key, value := range fields {
+		object[key] = value
+	}
+	return dynamic.NewObject(&unstructured.Unstructured{Object: object}, kind, nil)
+}
+
+func TestTree(t *testing.T) {
+	deploymentKind := resource.Kind{Group: "apps", Version: "v1", Kind: "Deployment", Scoped: true}
+	replicaSetKind := resource.Kind{Group: "apps", Version: "v1", Kind: "ReplicaSet", Scoped: true}
+	statefulSetKind := resource.Kind{Group: "apps", Version: "v1", Kind: "StatefulSet", Scoped: true}
+	podKind := resource.Kind{Version: "v1", Kind: "Pod", Scoped: true}
+	claimKind := resource.Kind{Version: "v1", Kind: "PersistentVolumeClaim", Scoped: true}
+	serviceKind := resource.Kind{Version: "v1", Kind: "Service", Scoped: true}
+	endpointsKind := resource.Kind{Version: "v1", Kind: "Endpoints", Scoped: true}
+
+	hash := map[string]interface{}{"pod-template-hash": "6c8d9f"}
+	tree, err := newTree([]*dynamic.Object{
+		newTreeObject(podKind, "web-6c8d9f-8j2lq", hash, "web-6c8d9f", map[string]interface{}{
+			"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "web"}}},
+			"status": map[string]interface{}{
+				"phase": "Running",
+				"containerStatuses": []interface{}{
+					map[string]interface{}{
+						"name":  "web",
+						"ready": false,
+						"state": map[string]interface{}{"waiting": map[string]interface{}{"reason": "CrashLoopBackOff"}},
+					},
+				},
+			},
+		}),
+		newTreeObject(podKind, "web-6c8d9f-4xkzp", hash, "web-6c8d9f", map[string]interface{}{
+			"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "web"}}},
+			"status": map[string]interface{}{
+				"phase":             "Running",
+				"containerStatuses": []interface{}{map[string]interface{}{"name": "web", "ready": true}},
+			},
+		}),
+		newTreeObject(replicaSetKind, "web-6c8d9f", hash, "web", map[string]interface{}{
+			"spec":   map[string]interface{}{"replicas": int64(2)},
+			"status": map[string]interface{}{"readyReplicas": int64(1)},
+		}),
+		newTreeObject(deploymentKind, "web", nil, "", map[string]interface{}{
+			"spec":   map[string]interface{}{"replicas": int64(2)},
+			"status": map[string]interface{}{"readyReplicas": int64(1)},
+		}),
+		newTreeObject(statefulSetKind, "raft", nil, "", map[string]interface{}{
+			"spec": map[string]interface{}{
+				"replicas": int64(1),
+				"volumeClaimTemplates": []interface{}{
+					map[string]interface{}{"metadata": map[string]interface{}{"name": "data"}},
+				},
+			},
+			"status": map[string]interface{}{"readyReplicas": int64(1)},
+		}),
+		newTreeObject(podKind, "raft-0", nil, "raft", nil),
+		newTreeObject(claimKind, "data-raft-0", nil, "", map[string]interface{}{
+			"status": map[string]interface{}{"phase": "Bound"},
+		}),
+		newTreeObject(claimKind, "logs-raft-0", nil, "", map[string]interface{}{
+			"status": map[string]interface{}{"phase": "Pending"},
+		}),
+		newTreeObject(serviceKind, "web-svc", nil, "", nil),
+		newTreeObject(endpointsKind, "web-svc", nil, "", nil),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, tree.Roots, 4)
+
+	deployment := tree.Roots[0]
+	assert.Equal(t, "Deployment", deployment.Kind.Kind)
+	assert.Equal(t, Degraded, deployment.Health)
+	assert.Len(t, deployment.Children, 1)
+	replicaSet := deployment.Children[0]
+	assert.Equal(t, "web-6c8d9f", replicaSet.Name)
+	assert.Len(t, replicaSet.Children, 2)
+	assert.Equal(t, "web-6c8d9f-4xkzp", replicaSet.Children[0].Name)
+	assert.Equal(t, Healthy, replicaSet.Children[0].Health)
+	assert.Equal(t, "web-6c8d9f-8j2lq", replicaSet.Children[1].Name)
+	assert.Equal(t, Failed, replicaSet.Children[1].Health)
+
+	// The claim that does not match a volume claim template is not nested
+	claim := tree.Roots[1]
+	assert.Equal(t, "logs-raft-0", claim.Name)
+	assert.Equal(t, Degraded, claim.Health)
+
+	service := tree.Roots[2]
+	assert.Equal(t, "Service", service.Kind.Kind)
+	assert.Equal(t, Health(""), service.Health)
+	assert.Len(t, service.Children, 1)
+	assert.Equal(t, "Endpoints", service.Children[0].Kind.Kind)
+
+	statefulSet := tree.Roots[3]
+	assert.Equal(t, "StatefulSet", statefulSet.Kind.Kind)
+	assert.Equal(t, Healthy, statefulSet.Health)
+	assert.Len(t, statefulSet.Children, 2)
+	assert.Equal(t, "PersistentVolumeClaim", statefulSet.Children[0].Kind.Kind)
+	assert.Equal(t, Healthy, statefulSet.Children[0].Health)
+	assert.Equal(t, "Pod", statefulSet.Children[1].Kind.Kind)
+
+	lines := strings.Split(strings.TrimSpace(tree.String()), "\n")
+	assert.Len(t, lines, 11)
+	assert.Regexp(t, "^NAMESPACE +NAME +HEALTH +REASONS$", lines[0])
+	assert.Regexp(t, "^default +Deployment/web +degraded +1/2 replicas ready$", lines[1])
+	assert.Regexp(t, "^default +└─ReplicaSet/web-6c8d9f +degraded", lines[2])
+	assert.Regexp(t, "^default +  ├─Pod/web-6c8d9f-4xkzp +healthy", lines[3])
+	assert.Regexp(t, "^default +  └─Pod/web-6c8d9f-8j2lq +failed +container web: CrashLoopBackOff", lines[4])
+	assert.Regexp(t, "^default +Service/web-svc +-", lines[6])
+	assert.Regexp(t, "^default +└─Endpoints/web-svc +-", lines[7])
+	assert.Regexp(t, "^default +├─PersistentVolumeClaim/data-raft-0 +healthy", lines[9])
+	assert.Regexp(t, "^default +└─Pod/raft-0", lines[10])
+}
+
+func TestTreeOwnerCycle(t *testing.T) {
+	configMapKind := resource.Kind{Version: "v1", Kind: "ConfigMap", Scoped: true}
+	tree, err := newTree([]*dynamic.Object{
+		newTreeObject(configMapKind, "a", nil, "b", nil),
+		newTreeObject(configMapKind, "b", nil, "a", nil),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, tree.Roots, 1)
+	assert.Len(t, tree.Roots[0].Children, 1)
+}
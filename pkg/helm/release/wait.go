@@ -0,0 +1,139 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"context"
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Wait waits for all the waitable resources in the release to become ready. Pods and replica sets that are managed by
+// a controller are not waited on individually; the controller is ready once they are. Resources that are deleted
+// while waiting, e.g. pods replaced by scaling or a rolling update, are considered done.
+func (r *Release) Wait(ctx context.Context) error {
+	waiters, err := r.waiters()
+	if err != nil {
+		return err
+	}
+	for _, waiter := range waiters {
+		if err := resource.WaitContext(ctx, ignoreDeleted(waiter.Ready)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ignoreDeleted returns a ReadyFunc that is ready once the resource has been deleted
+func ignoreDeleted(ready resource.ReadyFunc) resource.ReadyFunc {
+	return func() (bool, error) {
+		ok, err := ready()
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return ok, err
+	}
+}
+
+// waiters returns the waitable resources in the release
+func (r *Release) waiters() ([]resource.Waiter, error) {
+	waiters := make([]resource.Waiter, 0)
+
+	crds, err := r.client.ApiextensionsV1().CustomResourceDefinitions().List()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	for _, crd := range crds {
+		waiters = append(waiters, crd)
+	}
+
+	legacyCRDs, err := r.client.ApiextensionsV1beta1().CustomResourceDefinitions().List()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	for _, crd := range legacyCRDs {
+		waiters = append(waiters, crd)
+	}
+
+	claims, err := r.client.CoreV1().PersistentVolumeClaims().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, claim := range claims {
+		waiters = append(waiters, claim)
+	}
+
+	deployments, err := r.client.AppsV1().Deployments().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments {
+		waiters = append(waiters, deployment)
+	}
+
+	replicaSets, err := r.client.AppsV1().ReplicaSets().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, replicaSet := range replicaSets {
+		if metav1.GetControllerOf(&replicaSet.Object.ObjectMeta) == nil {
+			waiters = append(waiters, replicaSet)
+		}
+	}
+
+	statefulSets, err := r.client.AppsV1().StatefulSets().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, statefulSet := range statefulSets {
+		waiters = append(waiters, statefulSet)
+	}
+
+	daemonSets, err := r.client.AppsV1().DaemonSets().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, daemonSet := range daemonSets {
+		waiters = append(waiters, daemonSet)
+	}
+
+	jobs, err := r.client.BatchV1().Jobs().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		waiters = append(waiters, job)
+	}
+
+	pods, err := r.client.CoreV1().Pods().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods {
+		if metav1.GetControllerOf(&pod.Object.ObjectMeta) == nil {
+			waiters = append(waiters, pod)
+		}
+	}
+
+	services, err := r.client.CoreV1().Services().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range services {
+		waiters = append(waiters, service)
+	}
+	return waiters, nil
+}
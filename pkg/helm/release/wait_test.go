@@ -0,0 +1,41 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"testing"
+)
+
+func TestIgnoreDeleted(t *testing.T) {
+	ready, err := ignoreDeleted(func() (bool, error) {
+		return false, errors.NewNotFound(schema.GroupResource{Resource: "pods"}, "onos-0")
+	})()
+	assert.NoError(t, err)
+	assert.True(t, ready)
+
+	ready, err = ignoreDeleted(func() (bool, error) {
+		return false, nil
+	})()
+	assert.NoError(t, err)
+	assert.False(t, ready)
+
+	_, err = ignoreDeleted(func() (bool, error) {
+		return false, errors.NewForbidden(schema.GroupResource{Resource: "pods"}, "onos-0", nil)
+	})()
+	assert.True(t, errors.IsForbidden(err))
+}
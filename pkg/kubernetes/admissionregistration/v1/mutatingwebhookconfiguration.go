@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -14,7 +15,7 @@ var MutatingWebhookConfigurationKind = resource.Kind{
 	Group:   "admissionregistration.k8s.io",
 	Version: "v1",
 	Kind:    "MutatingWebhookConfiguration",
-	Scoped:  true,
+	Scoped:  false,
 }
 
 var MutatingWebhookConfigurationResource = resource.Type{
@@ -23,6 +24,7 @@ var MutatingWebhookConfigurationResource = resource.Type{
 }
 
 func NewMutatingWebhookConfiguration(mutatingWebhookConfiguration *admissionregistrationv1.MutatingWebhookConfiguration, client resource.Client) *MutatingWebhookConfiguration {
+	client = resource.ForNamespace(client, mutatingWebhookConfiguration.Namespace)
 	return &MutatingWebhookConfiguration{
 		Resource: resource.NewResource(mutatingWebhookConfiguration.ObjectMeta, MutatingWebhookConfigurationKind, client),
 		Object:   mutatingWebhookConfiguration,
@@ -35,12 +37,11 @@ type MutatingWebhookConfiguration struct {
 }
 
 func (r *MutatingWebhookConfiguration) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.AdmissionregistrationV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, MutatingWebhookConfigurationKind.Scoped).
 		Resource(MutatingWebhookConfigurationResource.Name).
@@ -50,3 +51,94 @@ func (r *MutatingWebhookConfiguration) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *MutatingWebhookConfiguration) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, MutatingWebhookConfigurationKind.Scoped).
+		Resource(MutatingWebhookConfigurationResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *MutatingWebhookConfiguration) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, MutatingWebhookConfigurationKind.Scoped).
+		Resource(MutatingWebhookConfigurationResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *MutatingWebhookConfiguration) Update(mutate func(*admissionregistrationv1.MutatingWebhookConfiguration) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		mutatingWebhookConfiguration := r.Object.DeepCopy()
+		if err := mutate(mutatingWebhookConfiguration); err != nil {
+			return err
+		}
+		result := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, MutatingWebhookConfigurationKind.Scoped).
+			Resource(MutatingWebhookConfigurationResource.Name).
+			Name(r.Name).
+			Body(mutatingWebhookConfiguration).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *MutatingWebhookConfiguration) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *MutatingWebhookConfiguration) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *MutatingWebhookConfiguration) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *MutatingWebhookConfiguration) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
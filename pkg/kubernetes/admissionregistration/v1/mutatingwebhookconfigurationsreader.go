@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type MutatingWebhookConfigurationsReader interface {
 	Get(name string) (*MutatingWebhookConfiguration, error)
-	List() ([]*MutatingWebhookConfiguration, error)
+	List(opts ...resource.ListOption) ([]*MutatingWebhookConfiguration, error)
+	ListPages(handler func([]*MutatingWebhookConfiguration) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan MutatingWebhookConfigurationEvent, error)
+	WaitFor(ctx context.Context, predicate func(MutatingWebhookConfigurationEvent) (bool, error)) (MutatingWebhookConfigurationEvent, error)
+}
+
+type MutatingWebhookConfigurationEvent struct {
+	Type                         resource.EventType
+	MutatingWebhookConfiguration *MutatingWebhookConfiguration
 }
 
 func NewMutatingWebhookConfigurationsReader(client resource.Client, filter resource.Filter) MutatingWebhookConfigurationsReader {
@@ -30,24 +43,13 @@ type mutatingWebhookConfigurationsReader struct {
 }
 
 func (c *mutatingWebhookConfigurationsReader) Get(name string) (*MutatingWebhookConfiguration, error) {
-	mutatingWebhookConfiguration := &admissionregistrationv1.MutatingWebhookConfiguration{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.AdmissionregistrationV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), MutatingWebhookConfigurationKind.Scoped).
-		Resource(MutatingWebhookConfigurationResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(mutatingWebhookConfiguration)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, MutatingWebhookConfigurationKind.Scoped) {
+		mutatingWebhookConfiguration, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   MutatingWebhookConfigurationKind.Group,
 			Version: MutatingWebhookConfigurationKind.Version,
@@ -55,37 +57,123 @@ func (c *mutatingWebhookConfigurationsReader) Get(name string) (*MutatingWebhook
 		}, mutatingWebhookConfiguration.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewMutatingWebhookConfiguration(mutatingWebhookConfiguration, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    MutatingWebhookConfigurationKind.Group,
+		Resource: MutatingWebhookConfigurationResource.Name,
+	}, name)
+}
+
+func (c *mutatingWebhookConfigurationsReader) get(namespace, name string) (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, MutatingWebhookConfigurationKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    MutatingWebhookConfigurationKind.Group,
 				Resource: MutatingWebhookConfigurationResource.Name,
 			}, name)
 		}
+		return object.(*admissionregistrationv1.MutatingWebhookConfiguration).DeepCopy(), nil
 	}
-	return NewMutatingWebhookConfiguration(mutatingWebhookConfiguration, c.Client), nil
-}
 
-func (c *mutatingWebhookConfigurationsReader) List() ([]*MutatingWebhookConfiguration, error) {
-	list := &admissionregistrationv1.MutatingWebhookConfigurationList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.AdmissionregistrationV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), MutatingWebhookConfigurationKind.Scoped).
+		NamespaceIfScoped(namespace, MutatingWebhookConfigurationKind.Scoped).
 		Resource(MutatingWebhookConfigurationResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*MutatingWebhookConfiguration, 0, len(list.Items))
-	for _, mutatingWebhookConfiguration := range list.Items {
+func (c *mutatingWebhookConfigurationsReader) List(opts ...resource.ListOption) ([]*MutatingWebhookConfiguration, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, MutatingWebhookConfigurationKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*MutatingWebhookConfiguration, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *mutatingWebhookConfigurationsReader) ListPages(handler func([]*MutatingWebhookConfiguration) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, MutatingWebhookConfigurationKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *mutatingWebhookConfigurationsReader) list(namespace string, options metav1.ListOptions) ([]*MutatingWebhookConfiguration, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *mutatingWebhookConfigurationsReader) filterItems(items []admissionregistrationv1.MutatingWebhookConfiguration) ([]*MutatingWebhookConfiguration, error) {
+	results := make([]*MutatingWebhookConfiguration, 0, len(items))
+	for _, mutatingWebhookConfiguration := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   MutatingWebhookConfigurationKind.Group,
 			Version: MutatingWebhookConfigurationKind.Version,
@@ -100,3 +188,145 @@ func (c *mutatingWebhookConfigurationsReader) List() ([]*MutatingWebhookConfigur
 	}
 	return results, nil
 }
+
+func (c *mutatingWebhookConfigurationsReader) listItems(namespace string, options metav1.ListOptions) ([]admissionregistrationv1.MutatingWebhookConfiguration, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]admissionregistrationv1.MutatingWebhookConfiguration, 0, len(objects))
+			for _, object := range objects {
+				mutatingWebhookConfiguration := object.(*admissionregistrationv1.MutatingWebhookConfiguration)
+				if selector.Matches(labels.Set(mutatingWebhookConfiguration.Labels)) {
+					items = append(items, *mutatingWebhookConfiguration.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, MutatingWebhookConfigurationKind.Scoped).
+		Resource(MutatingWebhookConfigurationResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *mutatingWebhookConfigurationsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(MutatingWebhookConfigurationKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, MutatingWebhookConfigurationKind.Scoped).
+					Resource(MutatingWebhookConfigurationResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, MutatingWebhookConfigurationKind.Scoped).
+					Resource(MutatingWebhookConfigurationResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &admissionregistrationv1.MutatingWebhookConfiguration{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *mutatingWebhookConfigurationsReader) Watch(ctx context.Context) (<-chan MutatingWebhookConfigurationEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, MutatingWebhookConfigurationKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, MutatingWebhookConfigurationKind.Scoped).
+			Resource(MutatingWebhookConfigurationResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan MutatingWebhookConfigurationEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			mutatingWebhookConfiguration, ok := watchEvent.Object.(*admissionregistrationv1.MutatingWebhookConfiguration)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   MutatingWebhookConfigurationKind.Group,
+				Version: MutatingWebhookConfigurationKind.Version,
+				Kind:    MutatingWebhookConfigurationKind.Kind,
+			}, mutatingWebhookConfiguration.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- MutatingWebhookConfigurationEvent{Type: watchEvent.Type, MutatingWebhookConfiguration: NewMutatingWebhookConfiguration(mutatingWebhookConfiguration, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *mutatingWebhookConfigurationsReader) WaitFor(ctx context.Context, predicate func(MutatingWebhookConfigurationEvent) (bool, error)) (MutatingWebhookConfigurationEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return MutatingWebhookConfigurationEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return MutatingWebhookConfigurationEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return MutatingWebhookConfigurationEvent{}, ctx.Err()
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -14,7 +15,7 @@ var ValidatingWebhookConfigurationKind = resource.Kind{
 	Group:   "admissionregistration.k8s.io",
 	Version: "v1",
 	Kind:    "ValidatingWebhookConfiguration",
-	Scoped:  true,
+	Scoped:  false,
 }
 
 var ValidatingWebhookConfigurationResource = resource.Type{
@@ -23,6 +24,7 @@ var ValidatingWebhookConfigurationResource = resource.Type{
 }
 
 func NewValidatingWebhookConfiguration(validatingWebhookConfiguration *admissionregistrationv1.ValidatingWebhookConfiguration, client resource.Client) *ValidatingWebhookConfiguration {
+	client = resource.ForNamespace(client, validatingWebhookConfiguration.Namespace)
 	return &ValidatingWebhookConfiguration{
 		Resource: resource.NewResource(validatingWebhookConfiguration.ObjectMeta, ValidatingWebhookConfigurationKind, client),
 		Object:   validatingWebhookConfiguration,
@@ -35,12 +37,11 @@ type ValidatingWebhookConfiguration struct {
 }
 
 func (r *ValidatingWebhookConfiguration) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.AdmissionregistrationV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, ValidatingWebhookConfigurationKind.Scoped).
 		Resource(ValidatingWebhookConfigurationResource.Name).
@@ -50,3 +51,94 @@ func (r *ValidatingWebhookConfiguration) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *ValidatingWebhookConfiguration) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, ValidatingWebhookConfigurationKind.Scoped).
+		Resource(ValidatingWebhookConfigurationResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *ValidatingWebhookConfiguration) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, ValidatingWebhookConfigurationKind.Scoped).
+		Resource(ValidatingWebhookConfigurationResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *ValidatingWebhookConfiguration) Update(mutate func(*admissionregistrationv1.ValidatingWebhookConfiguration) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		validatingWebhookConfiguration := r.Object.DeepCopy()
+		if err := mutate(validatingWebhookConfiguration); err != nil {
+			return err
+		}
+		result := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, ValidatingWebhookConfigurationKind.Scoped).
+			Resource(ValidatingWebhookConfigurationResource.Name).
+			Name(r.Name).
+			Body(validatingWebhookConfiguration).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *ValidatingWebhookConfiguration) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *ValidatingWebhookConfiguration) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *ValidatingWebhookConfiguration) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *ValidatingWebhookConfiguration) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type ValidatingWebhookConfigurationsReader interface {
 	Get(name string) (*ValidatingWebhookConfiguration, error)
-	List() ([]*ValidatingWebhookConfiguration, error)
+	List(opts ...resource.ListOption) ([]*ValidatingWebhookConfiguration, error)
+	ListPages(handler func([]*ValidatingWebhookConfiguration) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan ValidatingWebhookConfigurationEvent, error)
+	WaitFor(ctx context.Context, predicate func(ValidatingWebhookConfigurationEvent) (bool, error)) (ValidatingWebhookConfigurationEvent, error)
+}
+
+type ValidatingWebhookConfigurationEvent struct {
+	Type                           resource.EventType
+	ValidatingWebhookConfiguration *ValidatingWebhookConfiguration
 }
 
 func NewValidatingWebhookConfigurationsReader(client resource.Client, filter resource.Filter) ValidatingWebhookConfigurationsReader {
@@ -30,24 +43,13 @@ type validatingWebhookConfigurationsReader struct {
 }
 
 func (c *validatingWebhookConfigurationsReader) Get(name string) (*ValidatingWebhookConfiguration, error) {
-	validatingWebhookConfiguration := &admissionregistrationv1.ValidatingWebhookConfiguration{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.AdmissionregistrationV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), ValidatingWebhookConfigurationKind.Scoped).
-		Resource(ValidatingWebhookConfigurationResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(validatingWebhookConfiguration)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, ValidatingWebhookConfigurationKind.Scoped) {
+		validatingWebhookConfiguration, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   ValidatingWebhookConfigurationKind.Group,
 			Version: ValidatingWebhookConfigurationKind.Version,
@@ -55,37 +57,123 @@ func (c *validatingWebhookConfigurationsReader) Get(name string) (*ValidatingWeb
 		}, validatingWebhookConfiguration.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewValidatingWebhookConfiguration(validatingWebhookConfiguration, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    ValidatingWebhookConfigurationKind.Group,
+		Resource: ValidatingWebhookConfigurationResource.Name,
+	}, name)
+}
+
+func (c *validatingWebhookConfigurationsReader) get(namespace, name string) (*admissionregistrationv1.ValidatingWebhookConfiguration, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, ValidatingWebhookConfigurationKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    ValidatingWebhookConfigurationKind.Group,
 				Resource: ValidatingWebhookConfigurationResource.Name,
 			}, name)
 		}
+		return object.(*admissionregistrationv1.ValidatingWebhookConfiguration).DeepCopy(), nil
 	}
-	return NewValidatingWebhookConfiguration(validatingWebhookConfiguration, c.Client), nil
-}
 
-func (c *validatingWebhookConfigurationsReader) List() ([]*ValidatingWebhookConfiguration, error) {
-	list := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.AdmissionregistrationV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), ValidatingWebhookConfigurationKind.Scoped).
+		NamespaceIfScoped(namespace, ValidatingWebhookConfigurationKind.Scoped).
 		Resource(ValidatingWebhookConfigurationResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*ValidatingWebhookConfiguration, 0, len(list.Items))
-	for _, validatingWebhookConfiguration := range list.Items {
+func (c *validatingWebhookConfigurationsReader) List(opts ...resource.ListOption) ([]*ValidatingWebhookConfiguration, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, ValidatingWebhookConfigurationKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*ValidatingWebhookConfiguration, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *validatingWebhookConfigurationsReader) ListPages(handler func([]*ValidatingWebhookConfiguration) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, ValidatingWebhookConfigurationKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *validatingWebhookConfigurationsReader) list(namespace string, options metav1.ListOptions) ([]*ValidatingWebhookConfiguration, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *validatingWebhookConfigurationsReader) filterItems(items []admissionregistrationv1.ValidatingWebhookConfiguration) ([]*ValidatingWebhookConfiguration, error) {
+	results := make([]*ValidatingWebhookConfiguration, 0, len(items))
+	for _, validatingWebhookConfiguration := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   ValidatingWebhookConfigurationKind.Group,
 			Version: ValidatingWebhookConfigurationKind.Version,
@@ -100,3 +188,145 @@ func (c *validatingWebhookConfigurationsReader) List() ([]*ValidatingWebhookConf
 	}
 	return results, nil
 }
+
+func (c *validatingWebhookConfigurationsReader) listItems(namespace string, options metav1.ListOptions) ([]admissionregistrationv1.ValidatingWebhookConfiguration, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]admissionregistrationv1.ValidatingWebhookConfiguration, 0, len(objects))
+			for _, object := range objects {
+				validatingWebhookConfiguration := object.(*admissionregistrationv1.ValidatingWebhookConfiguration)
+				if selector.Matches(labels.Set(validatingWebhookConfiguration.Labels)) {
+					items = append(items, *validatingWebhookConfiguration.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, ValidatingWebhookConfigurationKind.Scoped).
+		Resource(ValidatingWebhookConfigurationResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *validatingWebhookConfigurationsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(ValidatingWebhookConfigurationKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, ValidatingWebhookConfigurationKind.Scoped).
+					Resource(ValidatingWebhookConfigurationResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, ValidatingWebhookConfigurationKind.Scoped).
+					Resource(ValidatingWebhookConfigurationResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &admissionregistrationv1.ValidatingWebhookConfiguration{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *validatingWebhookConfigurationsReader) Watch(ctx context.Context) (<-chan ValidatingWebhookConfigurationEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, ValidatingWebhookConfigurationKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, ValidatingWebhookConfigurationKind.Scoped).
+			Resource(ValidatingWebhookConfigurationResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ValidatingWebhookConfigurationEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			validatingWebhookConfiguration, ok := watchEvent.Object.(*admissionregistrationv1.ValidatingWebhookConfiguration)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   ValidatingWebhookConfigurationKind.Group,
+				Version: ValidatingWebhookConfigurationKind.Version,
+				Kind:    ValidatingWebhookConfigurationKind.Kind,
+			}, validatingWebhookConfiguration.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- ValidatingWebhookConfigurationEvent{Type: watchEvent.Type, ValidatingWebhookConfiguration: NewValidatingWebhookConfiguration(validatingWebhookConfiguration, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *validatingWebhookConfigurationsReader) WaitFor(ctx context.Context, predicate func(ValidatingWebhookConfigurationEvent) (bool, error)) (ValidatingWebhookConfigurationEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return ValidatingWebhookConfigurationEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return ValidatingWebhookConfigurationEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return ValidatingWebhookConfigurationEvent{}, ctx.Err()
+}
@@ -4,6 +4,8 @@ package v1
 
 import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	clientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/rest"
 )
 
 type Client interface {
@@ -21,3 +23,21 @@ type client struct {
 	resource.Client
 	CustomResourceDefinitionsClient
 }
+
+func getClientset(client resource.Client) (clientset.Interface, error) {
+	typed, err := client.TypedClientset("k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset", func(config *rest.Config) (interface{}, error) {
+		return clientset.NewForConfig(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return typed.(clientset.Interface), nil
+}
+
+func getRESTClient(client resource.Client) (rest.Interface, error) {
+	clientset, err := getClientset(client)
+	if err != nil {
+		return nil, err
+	}
+	return clientset.ApiextensionsV1().RESTClient(), nil
+}
@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	clientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type CustomResourceDefinitionsReader interface {
 	Get(name string) (*CustomResourceDefinition, error)
-	List() ([]*CustomResourceDefinition, error)
+	List(opts ...resource.ListOption) ([]*CustomResourceDefinition, error)
+	ListPages(handler func([]*CustomResourceDefinition) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan CustomResourceDefinitionEvent, error)
+	WaitFor(ctx context.Context, predicate func(CustomResourceDefinitionEvent) (bool, error)) (CustomResourceDefinitionEvent, error)
+}
+
+type CustomResourceDefinitionEvent struct {
+	Type                     resource.EventType
+	CustomResourceDefinition *CustomResourceDefinition
 }
 
 func NewCustomResourceDefinitionsReader(client resource.Client, filter resource.Filter) CustomResourceDefinitionsReader {
@@ -30,24 +43,13 @@ type customResourceDefinitionsReader struct {
 }
 
 func (c *customResourceDefinitionsReader) Get(name string) (*CustomResourceDefinition, error) {
-	customResourceDefinition := &apiextensionsv1.CustomResourceDefinition{}
-	client, err := clientset.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.ApiextensionsV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), CustomResourceDefinitionKind.Scoped).
-		Resource(CustomResourceDefinitionResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(customResourceDefinition)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, CustomResourceDefinitionKind.Scoped) {
+		customResourceDefinition, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   CustomResourceDefinitionKind.Group,
 			Version: CustomResourceDefinitionKind.Version,
@@ -55,37 +57,123 @@ func (c *customResourceDefinitionsReader) Get(name string) (*CustomResourceDefin
 		}, customResourceDefinition.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewCustomResourceDefinition(customResourceDefinition, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    CustomResourceDefinitionKind.Group,
+		Resource: CustomResourceDefinitionResource.Name,
+	}, name)
+}
+
+func (c *customResourceDefinitionsReader) get(namespace, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, CustomResourceDefinitionKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    CustomResourceDefinitionKind.Group,
 				Resource: CustomResourceDefinitionResource.Name,
 			}, name)
 		}
+		return object.(*apiextensionsv1.CustomResourceDefinition).DeepCopy(), nil
 	}
-	return NewCustomResourceDefinition(customResourceDefinition, c.Client), nil
-}
 
-func (c *customResourceDefinitionsReader) List() ([]*CustomResourceDefinition, error) {
-	list := &apiextensionsv1.CustomResourceDefinitionList{}
-	client, err := clientset.NewForConfig(c.Config())
+	object := &apiextensionsv1.CustomResourceDefinition{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.ApiextensionsV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), CustomResourceDefinitionKind.Scoped).
+		NamespaceIfScoped(namespace, CustomResourceDefinitionKind.Scoped).
 		Resource(CustomResourceDefinitionResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*CustomResourceDefinition, 0, len(list.Items))
-	for _, customResourceDefinition := range list.Items {
+func (c *customResourceDefinitionsReader) List(opts ...resource.ListOption) ([]*CustomResourceDefinition, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, CustomResourceDefinitionKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*CustomResourceDefinition, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *customResourceDefinitionsReader) ListPages(handler func([]*CustomResourceDefinition) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, CustomResourceDefinitionKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *customResourceDefinitionsReader) list(namespace string, options metav1.ListOptions) ([]*CustomResourceDefinition, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *customResourceDefinitionsReader) filterItems(items []apiextensionsv1.CustomResourceDefinition) ([]*CustomResourceDefinition, error) {
+	results := make([]*CustomResourceDefinition, 0, len(items))
+	for _, customResourceDefinition := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   CustomResourceDefinitionKind.Group,
 			Version: CustomResourceDefinitionKind.Version,
@@ -100,3 +188,145 @@ func (c *customResourceDefinitionsReader) List() ([]*CustomResourceDefinition, e
 	}
 	return results, nil
 }
+
+func (c *customResourceDefinitionsReader) listItems(namespace string, options metav1.ListOptions) ([]apiextensionsv1.CustomResourceDefinition, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]apiextensionsv1.CustomResourceDefinition, 0, len(objects))
+			for _, object := range objects {
+				customResourceDefinition := object.(*apiextensionsv1.CustomResourceDefinition)
+				if selector.Matches(labels.Set(customResourceDefinition.Labels)) {
+					items = append(items, *customResourceDefinition.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &apiextensionsv1.CustomResourceDefinitionList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, CustomResourceDefinitionKind.Scoped).
+		Resource(CustomResourceDefinitionResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *customResourceDefinitionsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(CustomResourceDefinitionKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &apiextensionsv1.CustomResourceDefinitionList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, CustomResourceDefinitionKind.Scoped).
+					Resource(CustomResourceDefinitionResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, CustomResourceDefinitionKind.Scoped).
+					Resource(CustomResourceDefinitionResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &apiextensionsv1.CustomResourceDefinition{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *customResourceDefinitionsReader) Watch(ctx context.Context) (<-chan CustomResourceDefinitionEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, CustomResourceDefinitionKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, CustomResourceDefinitionKind.Scoped).
+			Resource(CustomResourceDefinitionResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan CustomResourceDefinitionEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			customResourceDefinition, ok := watchEvent.Object.(*apiextensionsv1.CustomResourceDefinition)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   CustomResourceDefinitionKind.Group,
+				Version: CustomResourceDefinitionKind.Version,
+				Kind:    CustomResourceDefinitionKind.Kind,
+			}, customResourceDefinition.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- CustomResourceDefinitionEvent{Type: watchEvent.Type, CustomResourceDefinition: NewCustomResourceDefinition(customResourceDefinition, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *customResourceDefinitionsReader) WaitFor(ctx context.Context, predicate func(CustomResourceDefinitionEvent) (bool, error)) (CustomResourceDefinitionEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return CustomResourceDefinitionEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return CustomResourceDefinitionEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return CustomResourceDefinitionEvent{}, ctx.Err()
+}
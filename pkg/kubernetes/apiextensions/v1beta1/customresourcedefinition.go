@@ -5,8 +5,9 @@ package v1beta1
 import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
-	clientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var CustomResourceDefinitionResource = resource.Type{
 }
 
 func NewCustomResourceDefinition(customResourceDefinition *apiextensionsv1beta1.CustomResourceDefinition, client resource.Client) *CustomResourceDefinition {
+	client = resource.ForNamespace(client, customResourceDefinition.Namespace)
 	return &CustomResourceDefinition{
 		Resource: resource.NewResource(customResourceDefinition.ObjectMeta, CustomResourceDefinitionKind, client),
 		Object:   customResourceDefinition,
@@ -35,12 +37,11 @@ type CustomResourceDefinition struct {
 }
 
 func (r *CustomResourceDefinition) Delete() error {
-	client, err := clientset.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.ApiextensionsV1beta1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, CustomResourceDefinitionKind.Scoped).
 		Resource(CustomResourceDefinitionResource.Name).
@@ -50,3 +51,94 @@ func (r *CustomResourceDefinition) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *CustomResourceDefinition) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &apiextensionsv1beta1.CustomResourceDefinition{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, CustomResourceDefinitionKind.Scoped).
+		Resource(CustomResourceDefinitionResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *CustomResourceDefinition) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &apiextensionsv1beta1.CustomResourceDefinition{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, CustomResourceDefinitionKind.Scoped).
+		Resource(CustomResourceDefinitionResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *CustomResourceDefinition) Update(mutate func(*apiextensionsv1beta1.CustomResourceDefinition) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		customResourceDefinition := r.Object.DeepCopy()
+		if err := mutate(customResourceDefinition); err != nil {
+			return err
+		}
+		result := &apiextensionsv1beta1.CustomResourceDefinition{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, CustomResourceDefinitionKind.Scoped).
+			Resource(CustomResourceDefinitionResource.Name).
+			Name(r.Name).
+			Body(customResourceDefinition).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *CustomResourceDefinition) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *CustomResourceDefinition) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *CustomResourceDefinition) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *CustomResourceDefinition) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
@@ -0,0 +1,51 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+// Ready returns whether the custom resource definition has been established
+func (r *CustomResourceDefinition) Ready() (bool, error) {
+	client, err := getClientset(r.Client)
+	if err != nil {
+		return false, err
+	}
+	crd, err := client.ApiextensionsV1beta1().CustomResourceDefinitions().Get(r.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return isCustomResourceDefinitionEstablished(crd), nil
+}
+
+// Wait waits for the custom resource definition to be established
+func (r *CustomResourceDefinition) Wait(timeout time.Duration) error {
+	return resource.Wait(r.Ready, timeout)
+}
+
+func isCustomResourceDefinitionEstablished(crd *apiextensionsv1beta1.CustomResourceDefinition) bool {
+	for _, condition := range crd.Status.Conditions {
+		if condition.Type == apiextensionsv1beta1.Established {
+			return condition.Status == apiextensionsv1beta1.ConditionTrue
+		}
+	}
+	return false
+}
+
+var _ resource.Waiter = &CustomResourceDefinition{}
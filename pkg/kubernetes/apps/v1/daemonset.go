@@ -7,7 +7,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -24,6 +25,7 @@ var DaemonSetResource = resource.Type{
 }
 
 func NewDaemonSet(daemonSet *appsv1.DaemonSet, client resource.Client) *DaemonSet {
+	client = resource.ForNamespace(client, daemonSet.Namespace)
 	return &DaemonSet{
 		Resource:      resource.NewResource(daemonSet.ObjectMeta, DaemonSetKind, client),
 		Object:        daemonSet,
@@ -38,12 +40,11 @@ type DaemonSet struct {
 }
 
 func (r *DaemonSet) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.AppsV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, DaemonSetKind.Scoped).
 		Resource(DaemonSetResource.Name).
@@ -53,3 +54,94 @@ func (r *DaemonSet) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *DaemonSet) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &appsv1.DaemonSet{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, DaemonSetKind.Scoped).
+		Resource(DaemonSetResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *DaemonSet) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &appsv1.DaemonSet{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, DaemonSetKind.Scoped).
+		Resource(DaemonSetResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *DaemonSet) Update(mutate func(*appsv1.DaemonSet) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		daemonSet := r.Object.DeepCopy()
+		if err := mutate(daemonSet); err != nil {
+			return err
+		}
+		result := &appsv1.DaemonSet{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, DaemonSetKind.Scoped).
+			Resource(DaemonSetResource.Name).
+			Name(r.Name).
+			Body(daemonSet).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *DaemonSet) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *DaemonSet) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *DaemonSet) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *DaemonSet) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
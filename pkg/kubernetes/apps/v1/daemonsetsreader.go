@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type DaemonSetsReader interface {
 	Get(name string) (*DaemonSet, error)
-	List() ([]*DaemonSet, error)
+	List(opts ...resource.ListOption) ([]*DaemonSet, error)
+	ListPages(handler func([]*DaemonSet) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan DaemonSetEvent, error)
+	WaitFor(ctx context.Context, predicate func(DaemonSetEvent) (bool, error)) (DaemonSetEvent, error)
+}
+
+type DaemonSetEvent struct {
+	Type      resource.EventType
+	DaemonSet *DaemonSet
 }
 
 func NewDaemonSetsReader(client resource.Client, filter resource.Filter) DaemonSetsReader {
@@ -30,24 +43,13 @@ type daemonSetsReader struct {
 }
 
 func (c *daemonSetsReader) Get(name string) (*DaemonSet, error) {
-	daemonSet := &appsv1.DaemonSet{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.AppsV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), DaemonSetKind.Scoped).
-		Resource(DaemonSetResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(daemonSet)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, DaemonSetKind.Scoped) {
+		daemonSet, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   DaemonSetKind.Group,
 			Version: DaemonSetKind.Version,
@@ -55,37 +57,123 @@ func (c *daemonSetsReader) Get(name string) (*DaemonSet, error) {
 		}, daemonSet.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewDaemonSet(daemonSet, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    DaemonSetKind.Group,
+		Resource: DaemonSetResource.Name,
+	}, name)
+}
+
+func (c *daemonSetsReader) get(namespace, name string) (*appsv1.DaemonSet, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, DaemonSetKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    DaemonSetKind.Group,
 				Resource: DaemonSetResource.Name,
 			}, name)
 		}
+		return object.(*appsv1.DaemonSet).DeepCopy(), nil
 	}
-	return NewDaemonSet(daemonSet, c.Client), nil
-}
 
-func (c *daemonSetsReader) List() ([]*DaemonSet, error) {
-	list := &appsv1.DaemonSetList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &appsv1.DaemonSet{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.AppsV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), DaemonSetKind.Scoped).
+		NamespaceIfScoped(namespace, DaemonSetKind.Scoped).
 		Resource(DaemonSetResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*DaemonSet, 0, len(list.Items))
-	for _, daemonSet := range list.Items {
+func (c *daemonSetsReader) List(opts ...resource.ListOption) ([]*DaemonSet, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, DaemonSetKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*DaemonSet, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *daemonSetsReader) ListPages(handler func([]*DaemonSet) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, DaemonSetKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *daemonSetsReader) list(namespace string, options metav1.ListOptions) ([]*DaemonSet, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *daemonSetsReader) filterItems(items []appsv1.DaemonSet) ([]*DaemonSet, error) {
+	results := make([]*DaemonSet, 0, len(items))
+	for _, daemonSet := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   DaemonSetKind.Group,
 			Version: DaemonSetKind.Version,
@@ -100,3 +188,145 @@ func (c *daemonSetsReader) List() ([]*DaemonSet, error) {
 	}
 	return results, nil
 }
+
+func (c *daemonSetsReader) listItems(namespace string, options metav1.ListOptions) ([]appsv1.DaemonSet, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]appsv1.DaemonSet, 0, len(objects))
+			for _, object := range objects {
+				daemonSet := object.(*appsv1.DaemonSet)
+				if selector.Matches(labels.Set(daemonSet.Labels)) {
+					items = append(items, *daemonSet.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &appsv1.DaemonSetList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, DaemonSetKind.Scoped).
+		Resource(DaemonSetResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *daemonSetsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(DaemonSetKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &appsv1.DaemonSetList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, DaemonSetKind.Scoped).
+					Resource(DaemonSetResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, DaemonSetKind.Scoped).
+					Resource(DaemonSetResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &appsv1.DaemonSet{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *daemonSetsReader) Watch(ctx context.Context) (<-chan DaemonSetEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, DaemonSetKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, DaemonSetKind.Scoped).
+			Resource(DaemonSetResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan DaemonSetEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			daemonSet, ok := watchEvent.Object.(*appsv1.DaemonSet)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   DaemonSetKind.Group,
+				Version: DaemonSetKind.Version,
+				Kind:    DaemonSetKind.Kind,
+			}, daemonSet.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- DaemonSetEvent{Type: watchEvent.Type, DaemonSet: NewDaemonSet(daemonSet, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *daemonSetsReader) WaitFor(ctx context.Context, predicate func(DaemonSetEvent) (bool, error)) (DaemonSetEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return DaemonSetEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return DaemonSetEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return DaemonSetEvent{}, ctx.Err()
+}
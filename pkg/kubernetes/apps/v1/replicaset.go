@@ -7,7 +7,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -24,6 +25,7 @@ var ReplicaSetResource = resource.Type{
 }
 
 func NewReplicaSet(replicaSet *appsv1.ReplicaSet, client resource.Client) *ReplicaSet {
+	client = resource.ForNamespace(client, replicaSet.Namespace)
 	return &ReplicaSet{
 		Resource:      resource.NewResource(replicaSet.ObjectMeta, ReplicaSetKind, client),
 		Object:        replicaSet,
@@ -38,12 +40,11 @@ type ReplicaSet struct {
 }
 
 func (r *ReplicaSet) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.AppsV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, ReplicaSetKind.Scoped).
 		Resource(ReplicaSetResource.Name).
@@ -53,3 +54,94 @@ func (r *ReplicaSet) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *ReplicaSet) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &appsv1.ReplicaSet{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, ReplicaSetKind.Scoped).
+		Resource(ReplicaSetResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *ReplicaSet) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &appsv1.ReplicaSet{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, ReplicaSetKind.Scoped).
+		Resource(ReplicaSetResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *ReplicaSet) Update(mutate func(*appsv1.ReplicaSet) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		replicaSet := r.Object.DeepCopy()
+		if err := mutate(replicaSet); err != nil {
+			return err
+		}
+		result := &appsv1.ReplicaSet{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, ReplicaSetKind.Scoped).
+			Resource(ReplicaSetResource.Name).
+			Name(r.Name).
+			Body(replicaSet).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *ReplicaSet) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *ReplicaSet) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *ReplicaSet) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *ReplicaSet) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type ReplicaSetsReader interface {
 	Get(name string) (*ReplicaSet, error)
-	List() ([]*ReplicaSet, error)
+	List(opts ...resource.ListOption) ([]*ReplicaSet, error)
+	ListPages(handler func([]*ReplicaSet) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan ReplicaSetEvent, error)
+	WaitFor(ctx context.Context, predicate func(ReplicaSetEvent) (bool, error)) (ReplicaSetEvent, error)
+}
+
+type ReplicaSetEvent struct {
+	Type       resource.EventType
+	ReplicaSet *ReplicaSet
 }
 
 func NewReplicaSetsReader(client resource.Client, filter resource.Filter) ReplicaSetsReader {
@@ -30,24 +43,13 @@ type replicaSetsReader struct {
 }
 
 func (c *replicaSetsReader) Get(name string) (*ReplicaSet, error) {
-	replicaSet := &appsv1.ReplicaSet{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.AppsV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), ReplicaSetKind.Scoped).
-		Resource(ReplicaSetResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(replicaSet)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, ReplicaSetKind.Scoped) {
+		replicaSet, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   ReplicaSetKind.Group,
 			Version: ReplicaSetKind.Version,
@@ -55,37 +57,123 @@ func (c *replicaSetsReader) Get(name string) (*ReplicaSet, error) {
 		}, replicaSet.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewReplicaSet(replicaSet, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    ReplicaSetKind.Group,
+		Resource: ReplicaSetResource.Name,
+	}, name)
+}
+
+func (c *replicaSetsReader) get(namespace, name string) (*appsv1.ReplicaSet, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, ReplicaSetKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    ReplicaSetKind.Group,
 				Resource: ReplicaSetResource.Name,
 			}, name)
 		}
+		return object.(*appsv1.ReplicaSet).DeepCopy(), nil
 	}
-	return NewReplicaSet(replicaSet, c.Client), nil
-}
 
-func (c *replicaSetsReader) List() ([]*ReplicaSet, error) {
-	list := &appsv1.ReplicaSetList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &appsv1.ReplicaSet{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.AppsV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), ReplicaSetKind.Scoped).
+		NamespaceIfScoped(namespace, ReplicaSetKind.Scoped).
 		Resource(ReplicaSetResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*ReplicaSet, 0, len(list.Items))
-	for _, replicaSet := range list.Items {
+func (c *replicaSetsReader) List(opts ...resource.ListOption) ([]*ReplicaSet, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, ReplicaSetKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*ReplicaSet, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *replicaSetsReader) ListPages(handler func([]*ReplicaSet) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, ReplicaSetKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *replicaSetsReader) list(namespace string, options metav1.ListOptions) ([]*ReplicaSet, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *replicaSetsReader) filterItems(items []appsv1.ReplicaSet) ([]*ReplicaSet, error) {
+	results := make([]*ReplicaSet, 0, len(items))
+	for _, replicaSet := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   ReplicaSetKind.Group,
 			Version: ReplicaSetKind.Version,
@@ -100,3 +188,145 @@ func (c *replicaSetsReader) List() ([]*ReplicaSet, error) {
 	}
 	return results, nil
 }
+
+func (c *replicaSetsReader) listItems(namespace string, options metav1.ListOptions) ([]appsv1.ReplicaSet, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]appsv1.ReplicaSet, 0, len(objects))
+			for _, object := range objects {
+				replicaSet := object.(*appsv1.ReplicaSet)
+				if selector.Matches(labels.Set(replicaSet.Labels)) {
+					items = append(items, *replicaSet.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &appsv1.ReplicaSetList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, ReplicaSetKind.Scoped).
+		Resource(ReplicaSetResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *replicaSetsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(ReplicaSetKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &appsv1.ReplicaSetList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, ReplicaSetKind.Scoped).
+					Resource(ReplicaSetResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, ReplicaSetKind.Scoped).
+					Resource(ReplicaSetResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &appsv1.ReplicaSet{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *replicaSetsReader) Watch(ctx context.Context) (<-chan ReplicaSetEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, ReplicaSetKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, ReplicaSetKind.Scoped).
+			Resource(ReplicaSetResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ReplicaSetEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			replicaSet, ok := watchEvent.Object.(*appsv1.ReplicaSet)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   ReplicaSetKind.Group,
+				Version: ReplicaSetKind.Version,
+				Kind:    ReplicaSetKind.Kind,
+			}, replicaSet.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- ReplicaSetEvent{Type: watchEvent.Type, ReplicaSet: NewReplicaSet(replicaSet, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *replicaSetsReader) WaitFor(ctx context.Context, predicate func(ReplicaSetEvent) (bool, error)) (ReplicaSetEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return ReplicaSetEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return ReplicaSetEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return ReplicaSetEvent{}, ctx.Err()
+}
@@ -0,0 +1,248 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"time"
+)
+
+// restartedAtAnnotation is the pod template annotation used by 'kubectl rollout restart'
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// RolloutStatus is the status of a workload rollout
+type RolloutStatus struct {
+	// Complete indicates whether the rollout is complete
+	Complete bool
+	// Message is a human readable description of the rollout status
+	Message string
+}
+
+// Scale scales the deployment to the given number of replicas
+func (r *Deployment) Scale(replicas int32) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scale, err := r.Clientset().AppsV1().Deployments(r.Namespace).GetScale(r.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		scale.Spec.Replicas = replicas
+		_, err = r.Clientset().AppsV1().Deployments(r.Namespace).UpdateScale(r.Name, scale)
+		return err
+	})
+}
+
+// Restart restarts the deployment's pods in the same way as 'kubectl rollout restart'
+func (r *Deployment) Restart() error {
+	_, err := r.Clientset().AppsV1().Deployments(r.Namespace).Patch(r.Name, types.StrategicMergePatchType, getRestartPatch())
+	return err
+}
+
+// RolloutStatus returns the status of the deployment's rollout. If a timeout is provided, RolloutStatus waits
+// up to the timeout for the rollout to complete.
+func (r *Deployment) RolloutStatus(timeout ...time.Duration) (RolloutStatus, error) {
+	return getRolloutStatus(func() (RolloutStatus, error) {
+		deployment, err := r.Clientset().AppsV1().Deployments(r.Namespace).Get(r.Name, metav1.GetOptions{})
+		if err != nil {
+			return RolloutStatus{}, err
+		}
+		return getDeploymentRolloutStatus(deployment)
+	}, timeout...)
+}
+
+// Scale scales the stateful set to the given number of replicas
+func (r *StatefulSet) Scale(replicas int32) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scale, err := r.Clientset().AppsV1().StatefulSets(r.Namespace).GetScale(r.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		scale.Spec.Replicas = replicas
+		_, err = r.Clientset().AppsV1().StatefulSets(r.Namespace).UpdateScale(r.Name, scale)
+		return err
+	})
+}
+
+// Restart restarts the stateful set's pods in the same way as 'kubectl rollout restart'
+func (r *StatefulSet) Restart() error {
+	_, err := r.Clientset().AppsV1().StatefulSets(r.Namespace).Patch(r.Name, types.StrategicMergePatchType, getRestartPatch())
+	return err
+}
+
+// RolloutStatus returns the status of the stateful set's rollout. If a timeout is provided, RolloutStatus waits
+// up to the timeout for the rollout to complete.
+func (r *StatefulSet) RolloutStatus(timeout ...time.Duration) (RolloutStatus, error) {
+	return getRolloutStatus(func() (RolloutStatus, error) {
+		statefulSet, err := r.Clientset().AppsV1().StatefulSets(r.Namespace).Get(r.Name, metav1.GetOptions{})
+		if err != nil {
+			return RolloutStatus{}, err
+		}
+		return getStatefulSetRolloutStatus(statefulSet), nil
+	}, timeout...)
+}
+
+// Scale scales the replica set to the given number of replicas
+func (r *ReplicaSet) Scale(replicas int32) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scale, err := r.Clientset().AppsV1().ReplicaSets(r.Namespace).GetScale(r.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		scale.Spec.Replicas = replicas
+		_, err = r.Clientset().AppsV1().ReplicaSets(r.Namespace).UpdateScale(r.Name, scale)
+		return err
+	})
+}
+
+// Restart restarts the replica set's pods by deleting them and letting the replica set recreate them
+func (r *ReplicaSet) Restart() error {
+	replicaSet, err := r.Clientset().AppsV1().ReplicaSets(r.Namespace).Get(r.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(replicaSet.Spec.Selector)
+	if err != nil {
+		return err
+	}
+	pods, err := r.Clientset().CoreV1().Pods(r.Namespace).List(metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods.Items {
+		if !isOwnedBy(pod.ObjectMeta, replicaSet.UID) {
+			continue
+		}
+		if err := r.Clientset().CoreV1().Pods(r.Namespace).Delete(pod.Name, &metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RolloutStatus returns the status of the replica set's pods. If a timeout is provided, RolloutStatus waits
+// up to the timeout for all the replica set's pods to become available.
+func (r *ReplicaSet) RolloutStatus(timeout ...time.Duration) (RolloutStatus, error) {
+	return getRolloutStatus(func() (RolloutStatus, error) {
+		replicaSet, err := r.Clientset().AppsV1().ReplicaSets(r.Namespace).Get(r.Name, metav1.GetOptions{})
+		if err != nil {
+			return RolloutStatus{}, err
+		}
+		return getReplicaSetRolloutStatus(replicaSet), nil
+	}, timeout...)
+}
+
+// getRolloutStatus gets the rollout status, optionally waiting up to the given timeout for the rollout to complete
+func getRolloutStatus(get func() (RolloutStatus, error), timeout ...time.Duration) (RolloutStatus, error) {
+	if len(timeout) == 0 {
+		return get()
+	}
+	var status RolloutStatus
+	err := resource.Wait(func() (bool, error) {
+		s, err := get()
+		if err != nil {
+			return false, err
+		}
+		status = s
+		return status.Complete, nil
+	}, timeout[0])
+	return status, err
+}
+
+func getRestartPatch() []byte {
+	return []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, restartedAtAnnotation, time.Now().Format(time.RFC3339)))
+}
+
+func isOwnedBy(meta metav1.ObjectMeta, uid types.UID) bool {
+	for _, owner := range meta.OwnerReferences {
+		if owner.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+func getDeploymentRolloutStatus(deployment *appsv1.Deployment) (RolloutStatus, error) {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return RolloutStatus{
+			Message: "Waiting for deployment spec update to be observed...",
+		}, nil
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Status == corev1.ConditionFalse && condition.Reason == "ProgressDeadlineExceeded" {
+			return RolloutStatus{}, fmt.Errorf("deployment %q exceeded its progress deadline", deployment.Name)
+		}
+	}
+	replicas := getReplicas(deployment.Spec.Replicas)
+	if deployment.Status.UpdatedReplicas < replicas {
+		return RolloutStatus{
+			Message: fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated...", deployment.Name, deployment.Status.UpdatedReplicas, replicas),
+		}, nil
+	}
+	if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		return RolloutStatus{
+			Message: fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination...", deployment.Name, deployment.Status.Replicas-deployment.Status.UpdatedReplicas),
+		}, nil
+	}
+	if deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas {
+		return RolloutStatus{
+			Message: fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...", deployment.Name, deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas),
+		}, nil
+	}
+	return RolloutStatus{
+		Complete: true,
+		Message:  fmt.Sprintf("deployment %q successfully rolled out", deployment.Name),
+	}, nil
+}
+
+func getStatefulSetRolloutStatus(statefulSet *appsv1.StatefulSet) RolloutStatus {
+	if statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		return RolloutStatus{
+			Message: "Waiting for statefulset spec update to be observed...",
+		}
+	}
+	replicas := getReplicas(statefulSet.Spec.Replicas)
+	if statefulSet.Status.ReadyReplicas < replicas {
+		return RolloutStatus{
+			Message: fmt.Sprintf("Waiting for %d pods to be ready...", replicas-statefulSet.Status.ReadyReplicas),
+		}
+	}
+	if !isStatefulSetReady(statefulSet) {
+		return RolloutStatus{
+			Message: fmt.Sprintf("Waiting for statefulset %q rolling update to complete %d pods at revision %s...", statefulSet.Name, statefulSet.Status.UpdatedReplicas, statefulSet.Status.UpdateRevision),
+		}
+	}
+	return RolloutStatus{
+		Complete: true,
+		Message:  fmt.Sprintf("statefulset %q successfully rolled out", statefulSet.Name),
+	}
+}
+
+func getReplicaSetRolloutStatus(replicaSet *appsv1.ReplicaSet) RolloutStatus {
+	replicas := getReplicas(replicaSet.Spec.Replicas)
+	if !isReplicaSetReady(replicaSet) {
+		return RolloutStatus{
+			Message: fmt.Sprintf("Waiting for replicaset %q: %d of %d replicas are available...", replicaSet.Name, replicaSet.Status.AvailableReplicas, replicas),
+		}
+	}
+	return RolloutStatus{
+		Complete: true,
+		Message:  fmt.Sprintf("replicaset %q successfully rolled out", replicaSet.Name),
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestDeploymentRolloutStatus(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(3)},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           4,
+			UpdatedReplicas:    1,
+			AvailableReplicas:  3,
+		},
+	}
+	status, err := getDeploymentRolloutStatus(deployment)
+	assert.NoError(t, err)
+	assert.False(t, status.Complete)
+	assert.Equal(t, "Waiting for deployment \"foo\" rollout to finish: 1 out of 3 new replicas have been updated...", status.Message)
+
+	deployment.Status.UpdatedReplicas = 3
+	status, err = getDeploymentRolloutStatus(deployment)
+	assert.NoError(t, err)
+	assert.False(t, status.Complete)
+	assert.Equal(t, "Waiting for deployment \"foo\" rollout to finish: 1 old replicas are pending termination...", status.Message)
+
+	deployment.Status.Replicas = 3
+	status, err = getDeploymentRolloutStatus(deployment)
+	assert.NoError(t, err)
+	assert.True(t, status.Complete)
+
+	deployment.Status.Conditions = []appsv1.DeploymentCondition{
+		{
+			Type:   appsv1.DeploymentProgressing,
+			Status: corev1.ConditionFalse,
+			Reason: "ProgressDeadlineExceeded",
+		},
+	}
+	_, err = getDeploymentRolloutStatus(deployment)
+	assert.Error(t, err)
+}
+
+func TestStatefulSetRolloutStatus(t *testing.T) {
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Generation: 1},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: int32Ptr(3),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			},
+		},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 1,
+			ReadyReplicas:      2,
+			CurrentRevision:    "foo-1",
+			UpdateRevision:     "foo-2",
+		},
+	}
+	status := getStatefulSetRolloutStatus(statefulSet)
+	assert.False(t, status.Complete)
+	assert.Equal(t, "Waiting for 1 pods to be ready...", status.Message)
+
+	statefulSet.Status.ReadyReplicas = 3
+	status = getStatefulSetRolloutStatus(statefulSet)
+	assert.False(t, status.Complete)
+
+	statefulSet.Status.CurrentRevision = "foo-2"
+	status = getStatefulSetRolloutStatus(statefulSet)
+	assert.True(t, status.Complete)
+}
+
+func TestRestartPatch(t *testing.T) {
+	assert.Contains(t, string(getRestartPatch()), restartedAtAnnotation)
+}
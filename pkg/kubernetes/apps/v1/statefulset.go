@@ -7,7 +7,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -24,6 +25,7 @@ var StatefulSetResource = resource.Type{
 }
 
 func NewStatefulSet(statefulSet *appsv1.StatefulSet, client resource.Client) *StatefulSet {
+	client = resource.ForNamespace(client, statefulSet.Namespace)
 	return &StatefulSet{
 		Resource:      resource.NewResource(statefulSet.ObjectMeta, StatefulSetKind, client),
 		Object:        statefulSet,
@@ -38,12 +40,11 @@ type StatefulSet struct {
 }
 
 func (r *StatefulSet) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.AppsV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, StatefulSetKind.Scoped).
 		Resource(StatefulSetResource.Name).
@@ -53,3 +54,94 @@ func (r *StatefulSet) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *StatefulSet) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &appsv1.StatefulSet{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, StatefulSetKind.Scoped).
+		Resource(StatefulSetResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *StatefulSet) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &appsv1.StatefulSet{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, StatefulSetKind.Scoped).
+		Resource(StatefulSetResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *StatefulSet) Update(mutate func(*appsv1.StatefulSet) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		statefulSet := r.Object.DeepCopy()
+		if err := mutate(statefulSet); err != nil {
+			return err
+		}
+		result := &appsv1.StatefulSet{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, StatefulSetKind.Scoped).
+			Resource(StatefulSetResource.Name).
+			Name(r.Name).
+			Body(statefulSet).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *StatefulSet) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *StatefulSet) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *StatefulSet) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *StatefulSet) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
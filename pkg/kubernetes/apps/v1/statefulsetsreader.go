@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type StatefulSetsReader interface {
 	Get(name string) (*StatefulSet, error)
-	List() ([]*StatefulSet, error)
+	List(opts ...resource.ListOption) ([]*StatefulSet, error)
+	ListPages(handler func([]*StatefulSet) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan StatefulSetEvent, error)
+	WaitFor(ctx context.Context, predicate func(StatefulSetEvent) (bool, error)) (StatefulSetEvent, error)
+}
+
+type StatefulSetEvent struct {
+	Type        resource.EventType
+	StatefulSet *StatefulSet
 }
 
 func NewStatefulSetsReader(client resource.Client, filter resource.Filter) StatefulSetsReader {
@@ -30,24 +43,13 @@ type statefulSetsReader struct {
 }
 
 func (c *statefulSetsReader) Get(name string) (*StatefulSet, error) {
-	statefulSet := &appsv1.StatefulSet{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.AppsV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), StatefulSetKind.Scoped).
-		Resource(StatefulSetResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(statefulSet)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, StatefulSetKind.Scoped) {
+		statefulSet, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   StatefulSetKind.Group,
 			Version: StatefulSetKind.Version,
@@ -55,37 +57,123 @@ func (c *statefulSetsReader) Get(name string) (*StatefulSet, error) {
 		}, statefulSet.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewStatefulSet(statefulSet, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    StatefulSetKind.Group,
+		Resource: StatefulSetResource.Name,
+	}, name)
+}
+
+func (c *statefulSetsReader) get(namespace, name string) (*appsv1.StatefulSet, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, StatefulSetKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    StatefulSetKind.Group,
 				Resource: StatefulSetResource.Name,
 			}, name)
 		}
+		return object.(*appsv1.StatefulSet).DeepCopy(), nil
 	}
-	return NewStatefulSet(statefulSet, c.Client), nil
-}
 
-func (c *statefulSetsReader) List() ([]*StatefulSet, error) {
-	list := &appsv1.StatefulSetList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &appsv1.StatefulSet{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.AppsV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), StatefulSetKind.Scoped).
+		NamespaceIfScoped(namespace, StatefulSetKind.Scoped).
 		Resource(StatefulSetResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*StatefulSet, 0, len(list.Items))
-	for _, statefulSet := range list.Items {
+func (c *statefulSetsReader) List(opts ...resource.ListOption) ([]*StatefulSet, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, StatefulSetKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*StatefulSet, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *statefulSetsReader) ListPages(handler func([]*StatefulSet) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, StatefulSetKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *statefulSetsReader) list(namespace string, options metav1.ListOptions) ([]*StatefulSet, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *statefulSetsReader) filterItems(items []appsv1.StatefulSet) ([]*StatefulSet, error) {
+	results := make([]*StatefulSet, 0, len(items))
+	for _, statefulSet := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   StatefulSetKind.Group,
 			Version: StatefulSetKind.Version,
@@ -100,3 +188,145 @@ func (c *statefulSetsReader) List() ([]*StatefulSet, error) {
 	}
 	return results, nil
 }
+
+func (c *statefulSetsReader) listItems(namespace string, options metav1.ListOptions) ([]appsv1.StatefulSet, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]appsv1.StatefulSet, 0, len(objects))
+			for _, object := range objects {
+				statefulSet := object.(*appsv1.StatefulSet)
+				if selector.Matches(labels.Set(statefulSet.Labels)) {
+					items = append(items, *statefulSet.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &appsv1.StatefulSetList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, StatefulSetKind.Scoped).
+		Resource(StatefulSetResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *statefulSetsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(StatefulSetKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &appsv1.StatefulSetList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, StatefulSetKind.Scoped).
+					Resource(StatefulSetResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, StatefulSetKind.Scoped).
+					Resource(StatefulSetResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &appsv1.StatefulSet{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *statefulSetsReader) Watch(ctx context.Context) (<-chan StatefulSetEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, StatefulSetKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, StatefulSetKind.Scoped).
+			Resource(StatefulSetResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan StatefulSetEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			statefulSet, ok := watchEvent.Object.(*appsv1.StatefulSet)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   StatefulSetKind.Group,
+				Version: StatefulSetKind.Version,
+				Kind:    StatefulSetKind.Kind,
+			}, statefulSet.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- StatefulSetEvent{Type: watchEvent.Type, StatefulSet: NewStatefulSet(statefulSet, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *statefulSetsReader) WaitFor(ctx context.Context, predicate func(StatefulSetEvent) (bool, error)) (StatefulSetEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return StatefulSetEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return StatefulSetEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return StatefulSetEvent{}, ctx.Err()
+}
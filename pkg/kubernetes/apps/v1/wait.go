@@ -0,0 +1,137 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+// Ready returns whether the deployment's pods have all been updated and are available
+func (r *Deployment) Ready() (bool, error) {
+	deployment, err := r.Clientset().AppsV1().Deployments(r.Namespace).Get(r.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return isDeploymentReady(deployment), nil
+}
+
+// Wait waits for the deployment to become ready
+func (r *Deployment) Wait(timeout time.Duration) error {
+	return resource.Wait(r.Ready, timeout)
+}
+
+// Ready returns whether the stateful set's pods have all been updated and are ready
+func (r *StatefulSet) Ready() (bool, error) {
+	statefulSet, err := r.Clientset().AppsV1().StatefulSets(r.Namespace).Get(r.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return isStatefulSetReady(statefulSet), nil
+}
+
+// Wait waits for the stateful set to become ready
+func (r *StatefulSet) Wait(timeout time.Duration) error {
+	return resource.Wait(r.Ready, timeout)
+}
+
+// Ready returns whether the daemon set's pods have all been updated and are available
+func (r *DaemonSet) Ready() (bool, error) {
+	daemonSet, err := r.Clientset().AppsV1().DaemonSets(r.Namespace).Get(r.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return isDaemonSetReady(daemonSet), nil
+}
+
+// Wait waits for the daemon set to become ready
+func (r *DaemonSet) Wait(timeout time.Duration) error {
+	return resource.Wait(r.Ready, timeout)
+}
+
+// Ready returns whether the replica set's pods are all available
+func (r *ReplicaSet) Ready() (bool, error) {
+	replicaSet, err := r.Clientset().AppsV1().ReplicaSets(r.Namespace).Get(r.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return isReplicaSetReady(replicaSet), nil
+}
+
+// Wait waits for the replica set to become ready
+func (r *ReplicaSet) Wait(timeout time.Duration) error {
+	return resource.Wait(r.Ready, timeout)
+}
+
+func getReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func isDeploymentReady(deployment *appsv1.Deployment) bool {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return false
+	}
+	replicas := getReplicas(deployment.Spec.Replicas)
+	return deployment.Status.UpdatedReplicas >= replicas &&
+		deployment.Status.Replicas <= deployment.Status.UpdatedReplicas &&
+		deployment.Status.AvailableReplicas >= deployment.Status.UpdatedReplicas
+}
+
+func isStatefulSetReady(statefulSet *appsv1.StatefulSet) bool {
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+		return false
+	}
+	replicas := getReplicas(statefulSet.Spec.Replicas)
+	if statefulSet.Status.ReadyReplicas < replicas {
+		return false
+	}
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType {
+		rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate
+		if rollingUpdate != nil && rollingUpdate.Partition != nil && *rollingUpdate.Partition > 0 {
+			return statefulSet.Status.UpdatedReplicas >= replicas-*rollingUpdate.Partition
+		}
+		return statefulSet.Status.UpdateRevision == statefulSet.Status.CurrentRevision
+	}
+	return true
+}
+
+func isDaemonSetReady(daemonSet *appsv1.DaemonSet) bool {
+	if daemonSet.Status.ObservedGeneration < daemonSet.Generation {
+		return false
+	}
+	desired := daemonSet.Status.DesiredNumberScheduled
+	if daemonSet.Spec.UpdateStrategy.Type == appsv1.RollingUpdateDaemonSetStrategyType &&
+		daemonSet.Status.UpdatedNumberScheduled < desired {
+		return false
+	}
+	return daemonSet.Status.NumberAvailable >= desired
+}
+
+func isReplicaSetReady(replicaSet *appsv1.ReplicaSet) bool {
+	if replicaSet.Status.ObservedGeneration < replicaSet.Generation {
+		return false
+	}
+	return replicaSet.Status.AvailableReplicas >= getReplicas(replicaSet.Spec.Replicas)
+}
+
+var _ resource.Waiter = &Deployment{}
+var _ resource.Waiter = &StatefulSet{}
+var _ resource.Waiter = &DaemonSet{}
+var _ resource.Waiter = &ReplicaSet{}
@@ -0,0 +1,111 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestDeploymentReady(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(3)},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           3,
+			UpdatedReplicas:    3,
+			AvailableReplicas:  3,
+		},
+	}
+	assert.False(t, isDeploymentReady(deployment))
+
+	deployment.Status.ObservedGeneration = 2
+	assert.True(t, isDeploymentReady(deployment))
+
+	deployment.Status.Replicas = 4
+	assert.False(t, isDeploymentReady(deployment))
+
+	deployment.Status.Replicas = 3
+	deployment.Status.AvailableReplicas = 2
+	assert.False(t, isDeploymentReady(deployment))
+}
+
+func TestStatefulSetReady(t *testing.T) {
+	statefulSet := &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: int32Ptr(3),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			},
+		},
+		Status: appsv1.StatefulSetStatus{
+			ReadyReplicas:   3,
+			UpdatedReplicas: 1,
+			CurrentRevision: "a",
+			UpdateRevision:  "b",
+		},
+	}
+	assert.False(t, isStatefulSetReady(statefulSet))
+
+	statefulSet.Status.CurrentRevision = "b"
+	assert.True(t, isStatefulSetReady(statefulSet))
+
+	statefulSet.Status.CurrentRevision = "a"
+	statefulSet.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{Partition: int32Ptr(2)}
+	assert.True(t, isStatefulSetReady(statefulSet))
+
+	statefulSet.Status.ReadyReplicas = 2
+	assert.False(t, isStatefulSetReady(statefulSet))
+}
+
+func TestDaemonSetReady(t *testing.T) {
+	daemonSet := &appsv1.DaemonSet{
+		Spec: appsv1.DaemonSetSpec{
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+			},
+		},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 2,
+			UpdatedNumberScheduled: 1,
+			NumberAvailable:        2,
+		},
+	}
+	assert.False(t, isDaemonSetReady(daemonSet))
+
+	daemonSet.Status.UpdatedNumberScheduled = 2
+	assert.True(t, isDaemonSetReady(daemonSet))
+}
+
+func TestReplicaSetReady(t *testing.T) {
+	replicaSet := &appsv1.ReplicaSet{
+		Status: appsv1.ReplicaSetStatus{},
+	}
+	assert.False(t, isReplicaSetReady(replicaSet))
+
+	replicaSet.Status.AvailableReplicas = 1
+	assert.True(t, isReplicaSetReady(replicaSet))
+
+	replicaSet.Spec.Replicas = int32Ptr(0)
+	replicaSet.Status.AvailableReplicas = 0
+	assert.True(t, isReplicaSetReady(replicaSet))
+}
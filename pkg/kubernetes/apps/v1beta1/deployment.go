@@ -8,7 +8,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	appsv1beta1 "k8s.io/api/apps/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -25,6 +26,7 @@ var DeploymentResource = resource.Type{
 }
 
 func NewDeployment(deployment *appsv1beta1.Deployment, client resource.Client) *Deployment {
+	client = resource.ForNamespace(client, deployment.Namespace)
 	return &Deployment{
 		Resource:             resource.NewResource(deployment.ObjectMeta, DeploymentKind, client),
 		Object:               deployment,
@@ -41,12 +43,11 @@ type Deployment struct {
 }
 
 func (r *Deployment) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.AppsV1beta1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, DeploymentKind.Scoped).
 		Resource(DeploymentResource.Name).
@@ -56,3 +57,94 @@ func (r *Deployment) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *Deployment) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &appsv1beta1.Deployment{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, DeploymentKind.Scoped).
+		Resource(DeploymentResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Deployment) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &appsv1beta1.Deployment{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, DeploymentKind.Scoped).
+		Resource(DeploymentResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Deployment) Update(mutate func(*appsv1beta1.Deployment) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		deployment := r.Object.DeepCopy()
+		if err := mutate(deployment); err != nil {
+			return err
+		}
+		result := &appsv1beta1.Deployment{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, DeploymentKind.Scoped).
+			Resource(DeploymentResource.Name).
+			Name(r.Name).
+			Body(deployment).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *Deployment) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *Deployment) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *Deployment) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *Deployment) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
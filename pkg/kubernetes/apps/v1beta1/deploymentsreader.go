@@ -3,18 +3,31 @@
 package v1beta1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	appsv1beta1 "k8s.io/api/apps/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type DeploymentsReader interface {
 	Get(name string) (*Deployment, error)
-	List() ([]*Deployment, error)
+	List(opts ...resource.ListOption) ([]*Deployment, error)
+	ListPages(handler func([]*Deployment) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan DeploymentEvent, error)
+	WaitFor(ctx context.Context, predicate func(DeploymentEvent) (bool, error)) (DeploymentEvent, error)
+}
+
+type DeploymentEvent struct {
+	Type       resource.EventType
+	Deployment *Deployment
 }
 
 func NewDeploymentsReader(client resource.Client, filter resource.Filter) DeploymentsReader {
@@ -30,24 +43,13 @@ type deploymentsReader struct {
 }
 
 func (c *deploymentsReader) Get(name string) (*Deployment, error) {
-	deployment := &appsv1beta1.Deployment{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.AppsV1beta1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), DeploymentKind.Scoped).
-		Resource(DeploymentResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(deployment)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, DeploymentKind.Scoped) {
+		deployment, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   DeploymentKind.Group,
 			Version: DeploymentKind.Version,
@@ -55,37 +57,123 @@ func (c *deploymentsReader) Get(name string) (*Deployment, error) {
 		}, deployment.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewDeployment(deployment, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    DeploymentKind.Group,
+		Resource: DeploymentResource.Name,
+	}, name)
+}
+
+func (c *deploymentsReader) get(namespace, name string) (*appsv1beta1.Deployment, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, DeploymentKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    DeploymentKind.Group,
 				Resource: DeploymentResource.Name,
 			}, name)
 		}
+		return object.(*appsv1beta1.Deployment).DeepCopy(), nil
 	}
-	return NewDeployment(deployment, c.Client), nil
-}
 
-func (c *deploymentsReader) List() ([]*Deployment, error) {
-	list := &appsv1beta1.DeploymentList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &appsv1beta1.Deployment{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.AppsV1beta1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), DeploymentKind.Scoped).
+		NamespaceIfScoped(namespace, DeploymentKind.Scoped).
 		Resource(DeploymentResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*Deployment, 0, len(list.Items))
-	for _, deployment := range list.Items {
+func (c *deploymentsReader) List(opts ...resource.ListOption) ([]*Deployment, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, DeploymentKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*Deployment, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *deploymentsReader) ListPages(handler func([]*Deployment) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, DeploymentKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *deploymentsReader) list(namespace string, options metav1.ListOptions) ([]*Deployment, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *deploymentsReader) filterItems(items []appsv1beta1.Deployment) ([]*Deployment, error) {
+	results := make([]*Deployment, 0, len(items))
+	for _, deployment := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   DeploymentKind.Group,
 			Version: DeploymentKind.Version,
@@ -100,3 +188,145 @@ func (c *deploymentsReader) List() ([]*Deployment, error) {
 	}
 	return results, nil
 }
+
+func (c *deploymentsReader) listItems(namespace string, options metav1.ListOptions) ([]appsv1beta1.Deployment, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]appsv1beta1.Deployment, 0, len(objects))
+			for _, object := range objects {
+				deployment := object.(*appsv1beta1.Deployment)
+				if selector.Matches(labels.Set(deployment.Labels)) {
+					items = append(items, *deployment.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &appsv1beta1.DeploymentList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, DeploymentKind.Scoped).
+		Resource(DeploymentResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *deploymentsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(DeploymentKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &appsv1beta1.DeploymentList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, DeploymentKind.Scoped).
+					Resource(DeploymentResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, DeploymentKind.Scoped).
+					Resource(DeploymentResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &appsv1beta1.Deployment{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *deploymentsReader) Watch(ctx context.Context) (<-chan DeploymentEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, DeploymentKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, DeploymentKind.Scoped).
+			Resource(DeploymentResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan DeploymentEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			deployment, ok := watchEvent.Object.(*appsv1beta1.Deployment)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   DeploymentKind.Group,
+				Version: DeploymentKind.Version,
+				Kind:    DeploymentKind.Kind,
+			}, deployment.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- DeploymentEvent{Type: watchEvent.Type, Deployment: NewDeployment(deployment, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *deploymentsReader) WaitFor(ctx context.Context, predicate func(DeploymentEvent) (bool, error)) (DeploymentEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return DeploymentEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return DeploymentEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return DeploymentEvent{}, ctx.Err()
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var JobResource = resource.Type{
 }
 
 func NewJob(job *batchv1.Job, client resource.Client) *Job {
+	client = resource.ForNamespace(client, job.Namespace)
 	return &Job{
 		Resource: resource.NewResource(job.ObjectMeta, JobKind, client),
 		Object:   job,
@@ -35,12 +37,11 @@ type Job struct {
 }
 
 func (r *Job) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.BatchV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, JobKind.Scoped).
 		Resource(JobResource.Name).
@@ -50,3 +51,94 @@ func (r *Job) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *Job) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &batchv1.Job{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, JobKind.Scoped).
+		Resource(JobResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Job) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &batchv1.Job{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, JobKind.Scoped).
+		Resource(JobResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Job) Update(mutate func(*batchv1.Job) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		job := r.Object.DeepCopy()
+		if err := mutate(job); err != nil {
+			return err
+		}
+		result := &batchv1.Job{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, JobKind.Scoped).
+			Resource(JobResource.Name).
+			Name(r.Name).
+			Body(job).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *Job) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *Job) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *Job) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *Job) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
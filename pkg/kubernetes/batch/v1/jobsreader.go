@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type JobsReader interface {
 	Get(name string) (*Job, error)
-	List() ([]*Job, error)
+	List(opts ...resource.ListOption) ([]*Job, error)
+	ListPages(handler func([]*Job) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan JobEvent, error)
+	WaitFor(ctx context.Context, predicate func(JobEvent) (bool, error)) (JobEvent, error)
+}
+
+type JobEvent struct {
+	Type resource.EventType
+	Job  *Job
 }
 
 func NewJobsReader(client resource.Client, filter resource.Filter) JobsReader {
@@ -30,24 +43,13 @@ type jobsReader struct {
 }
 
 func (c *jobsReader) Get(name string) (*Job, error) {
-	job := &batchv1.Job{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.BatchV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), JobKind.Scoped).
-		Resource(JobResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(job)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, JobKind.Scoped) {
+		job, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   JobKind.Group,
 			Version: JobKind.Version,
@@ -55,37 +57,123 @@ func (c *jobsReader) Get(name string) (*Job, error) {
 		}, job.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewJob(job, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    JobKind.Group,
+		Resource: JobResource.Name,
+	}, name)
+}
+
+func (c *jobsReader) get(namespace, name string) (*batchv1.Job, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, JobKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    JobKind.Group,
 				Resource: JobResource.Name,
 			}, name)
 		}
+		return object.(*batchv1.Job).DeepCopy(), nil
 	}
-	return NewJob(job, c.Client), nil
-}
 
-func (c *jobsReader) List() ([]*Job, error) {
-	list := &batchv1.JobList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &batchv1.Job{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.BatchV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), JobKind.Scoped).
+		NamespaceIfScoped(namespace, JobKind.Scoped).
 		Resource(JobResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*Job, 0, len(list.Items))
-	for _, job := range list.Items {
+func (c *jobsReader) List(opts ...resource.ListOption) ([]*Job, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, JobKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*Job, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *jobsReader) ListPages(handler func([]*Job) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, JobKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *jobsReader) list(namespace string, options metav1.ListOptions) ([]*Job, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *jobsReader) filterItems(items []batchv1.Job) ([]*Job, error) {
+	results := make([]*Job, 0, len(items))
+	for _, job := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   JobKind.Group,
 			Version: JobKind.Version,
@@ -100,3 +188,145 @@ func (c *jobsReader) List() ([]*Job, error) {
 	}
 	return results, nil
 }
+
+func (c *jobsReader) listItems(namespace string, options metav1.ListOptions) ([]batchv1.Job, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]batchv1.Job, 0, len(objects))
+			for _, object := range objects {
+				job := object.(*batchv1.Job)
+				if selector.Matches(labels.Set(job.Labels)) {
+					items = append(items, *job.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &batchv1.JobList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, JobKind.Scoped).
+		Resource(JobResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *jobsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(JobKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &batchv1.JobList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, JobKind.Scoped).
+					Resource(JobResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, JobKind.Scoped).
+					Resource(JobResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &batchv1.Job{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *jobsReader) Watch(ctx context.Context) (<-chan JobEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, JobKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, JobKind.Scoped).
+			Resource(JobResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan JobEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			job, ok := watchEvent.Object.(*batchv1.Job)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   JobKind.Group,
+				Version: JobKind.Version,
+				Kind:    JobKind.Kind,
+			}, job.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- JobEvent{Type: watchEvent.Type, Job: NewJob(job, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *jobsReader) WaitFor(ctx context.Context, predicate func(JobEvent) (bool, error)) (JobEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return JobEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return JobEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return JobEvent{}, ctx.Err()
+}
@@ -0,0 +1,55 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+// Ready returns whether the job has completed, or an error if the job failed
+func (r *Job) Ready() (bool, error) {
+	job, err := r.Clientset().BatchV1().Jobs(r.Namespace).Get(r.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return isJobComplete(job)
+}
+
+// Wait waits for the job to complete
+func (r *Job) Wait(timeout time.Duration) error {
+	return resource.Wait(r.Ready, timeout)
+}
+
+func isJobComplete(job *batchv1.Job) (bool, error) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			return false, fmt.Errorf("job %s failed: %s", job.Name, condition.Message)
+		}
+	}
+	return false, nil
+}
+
+var _ resource.Waiter = &Job{}
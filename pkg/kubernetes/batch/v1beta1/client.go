@@ -4,6 +4,8 @@ package v1beta1
 
 import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 type Client interface {
@@ -21,3 +23,15 @@ type client struct {
 	resource.Client
 	CronJobsClient
 }
+
+func getClientset(client resource.Client) (kubernetes.Interface, error) {
+	return client.Clientset(), nil
+}
+
+func getRESTClient(client resource.Client) (rest.Interface, error) {
+	clientset, err := getClientset(client)
+	if err != nil {
+		return nil, err
+	}
+	return clientset.BatchV1beta1().RESTClient(), nil
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var CronJobResource = resource.Type{
 }
 
 func NewCronJob(cronJob *batchv1beta1.CronJob, client resource.Client) *CronJob {
+	client = resource.ForNamespace(client, cronJob.Namespace)
 	return &CronJob{
 		Resource: resource.NewResource(cronJob.ObjectMeta, CronJobKind, client),
 		Object:   cronJob,
@@ -35,12 +37,11 @@ type CronJob struct {
 }
 
 func (r *CronJob) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.BatchV1beta1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, CronJobKind.Scoped).
 		Resource(CronJobResource.Name).
@@ -50,3 +51,94 @@ func (r *CronJob) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *CronJob) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &batchv1beta1.CronJob{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, CronJobKind.Scoped).
+		Resource(CronJobResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *CronJob) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &batchv1beta1.CronJob{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, CronJobKind.Scoped).
+		Resource(CronJobResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *CronJob) Update(mutate func(*batchv1beta1.CronJob) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		cronJob := r.Object.DeepCopy()
+		if err := mutate(cronJob); err != nil {
+			return err
+		}
+		result := &batchv1beta1.CronJob{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, CronJobKind.Scoped).
+			Resource(CronJobResource.Name).
+			Name(r.Name).
+			Body(cronJob).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *CronJob) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *CronJob) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *CronJob) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *CronJob) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
@@ -3,18 +3,31 @@
 package v2alpha1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	batchv2alpha1 "k8s.io/api/batch/v2alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type CronJobsReader interface {
 	Get(name string) (*CronJob, error)
-	List() ([]*CronJob, error)
+	List(opts ...resource.ListOption) ([]*CronJob, error)
+	ListPages(handler func([]*CronJob) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan CronJobEvent, error)
+	WaitFor(ctx context.Context, predicate func(CronJobEvent) (bool, error)) (CronJobEvent, error)
+}
+
+type CronJobEvent struct {
+	Type    resource.EventType
+	CronJob *CronJob
 }
 
 func NewCronJobsReader(client resource.Client, filter resource.Filter) CronJobsReader {
@@ -30,24 +43,13 @@ type cronJobsReader struct {
 }
 
 func (c *cronJobsReader) Get(name string) (*CronJob, error) {
-	cronJob := &batchv2alpha1.CronJob{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.BatchV2alpha1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), CronJobKind.Scoped).
-		Resource(CronJobResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(cronJob)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, CronJobKind.Scoped) {
+		cronJob, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   CronJobKind.Group,
 			Version: CronJobKind.Version,
@@ -55,37 +57,123 @@ func (c *cronJobsReader) Get(name string) (*CronJob, error) {
 		}, cronJob.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewCronJob(cronJob, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    CronJobKind.Group,
+		Resource: CronJobResource.Name,
+	}, name)
+}
+
+func (c *cronJobsReader) get(namespace, name string) (*batchv2alpha1.CronJob, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, CronJobKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    CronJobKind.Group,
 				Resource: CronJobResource.Name,
 			}, name)
 		}
+		return object.(*batchv2alpha1.CronJob).DeepCopy(), nil
 	}
-	return NewCronJob(cronJob, c.Client), nil
-}
 
-func (c *cronJobsReader) List() ([]*CronJob, error) {
-	list := &batchv2alpha1.CronJobList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &batchv2alpha1.CronJob{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.BatchV2alpha1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), CronJobKind.Scoped).
+		NamespaceIfScoped(namespace, CronJobKind.Scoped).
 		Resource(CronJobResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*CronJob, 0, len(list.Items))
-	for _, cronJob := range list.Items {
+func (c *cronJobsReader) List(opts ...resource.ListOption) ([]*CronJob, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, CronJobKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*CronJob, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *cronJobsReader) ListPages(handler func([]*CronJob) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, CronJobKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *cronJobsReader) list(namespace string, options metav1.ListOptions) ([]*CronJob, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *cronJobsReader) filterItems(items []batchv2alpha1.CronJob) ([]*CronJob, error) {
+	results := make([]*CronJob, 0, len(items))
+	for _, cronJob := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   CronJobKind.Group,
 			Version: CronJobKind.Version,
@@ -100,3 +188,145 @@ func (c *cronJobsReader) List() ([]*CronJob, error) {
 	}
 	return results, nil
 }
+
+func (c *cronJobsReader) listItems(namespace string, options metav1.ListOptions) ([]batchv2alpha1.CronJob, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]batchv2alpha1.CronJob, 0, len(objects))
+			for _, object := range objects {
+				cronJob := object.(*batchv2alpha1.CronJob)
+				if selector.Matches(labels.Set(cronJob.Labels)) {
+					items = append(items, *cronJob.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &batchv2alpha1.CronJobList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, CronJobKind.Scoped).
+		Resource(CronJobResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *cronJobsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(CronJobKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &batchv2alpha1.CronJobList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, CronJobKind.Scoped).
+					Resource(CronJobResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, CronJobKind.Scoped).
+					Resource(CronJobResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &batchv2alpha1.CronJob{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *cronJobsReader) Watch(ctx context.Context) (<-chan CronJobEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, CronJobKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, CronJobKind.Scoped).
+			Resource(CronJobResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan CronJobEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			cronJob, ok := watchEvent.Object.(*batchv2alpha1.CronJob)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   CronJobKind.Group,
+				Version: CronJobKind.Version,
+				Kind:    CronJobKind.Kind,
+			}, cronJob.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- CronJobEvent{Type: watchEvent.Type, CronJob: NewCronJob(cronJob, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *cronJobsReader) WaitFor(ctx context.Context, predicate func(CronJobEvent) (bool, error)) (CronJobEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return CronJobEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return CronJobEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return CronJobEvent{}, ctx.Err()
+}
@@ -8,17 +8,26 @@ import (
 	apiextensionsv1beta1 "github.com/onosproject/helm-go/pkg/kubernetes/apiextensions/v1beta1"
 	appsv1 "github.com/onosproject/helm-go/pkg/kubernetes/apps/v1"
 	appsv1beta1 "github.com/onosproject/helm-go/pkg/kubernetes/apps/v1beta1"
+	autoscalingv1 "github.com/onosproject/helm-go/pkg/kubernetes/autoscaling/v1"
 	batchv1 "github.com/onosproject/helm-go/pkg/kubernetes/batch/v1"
 	batchv1beta1 "github.com/onosproject/helm-go/pkg/kubernetes/batch/v1beta1"
 	batchv2alpha1 "github.com/onosproject/helm-go/pkg/kubernetes/batch/v2alpha1"
 	"github.com/onosproject/helm-go/pkg/kubernetes/config"
+	coordinationv1 "github.com/onosproject/helm-go/pkg/kubernetes/coordination/v1"
 	corev1 "github.com/onosproject/helm-go/pkg/kubernetes/core/v1"
+	discoveryv1beta1 "github.com/onosproject/helm-go/pkg/kubernetes/discovery/v1beta1"
+	"github.com/onosproject/helm-go/pkg/kubernetes/dynamic"
 	extensionsv1beta1 "github.com/onosproject/helm-go/pkg/kubernetes/extensions/v1beta1"
+	networkingv1 "github.com/onosproject/helm-go/pkg/kubernetes/networking/v1"
 	networkingv1beta1 "github.com/onosproject/helm-go/pkg/kubernetes/networking/v1beta1"
 	policyv1beta1 "github.com/onosproject/helm-go/pkg/kubernetes/policy/v1beta1"
 	rbacv1 "github.com/onosproject/helm-go/pkg/kubernetes/rbac/v1"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	schedulingv1 "github.com/onosproject/helm-go/pkg/kubernetes/scheduling/v1"
 	storagev1 "github.com/onosproject/helm-go/pkg/kubernetes/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubedynamic "k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
@@ -37,27 +46,63 @@ func NewOrDie() Client {
 	return client
 }
 
-// NewForNamespace returns a new Kubernetes client for the given namespace
-func NewForNamespace(namespace string) (Client, error) {
-	kubernetesConfig, err := config.GetRestConfig()
-	if err != nil {
-		return nil, err
+// Option is a Kubernetes client option
+type Option func(*options)
+
+type options struct {
+	clientset  kubernetes.Interface
+	clientsets *resource.Clientsets
+	cache      *resource.Cache
+	namespaces []string
+}
+
+// WithClientset returns an option that shares the given clientset rather than creating a new one. The client still
+// creates its own dynamic client and discovery cache; use WithClientsets to share those as well.
+func WithClientset(clientset kubernetes.Interface) Option {
+	return func(options *options) {
+		options.clientset = clientset
 	}
-	kubernetesClient, err := kubernetes.NewForConfig(kubernetesConfig)
-	if err != nil {
-		return nil, err
+}
+
+// WithClientsets returns an option that shares the given clientsets -- the clientset, dynamic client, discovery
+// cache and typed clientsets -- rather than creating new ones, e.g. WithClientsets(resource.GetClientsets(parent))
+func WithClientsets(clientsets *resource.Clientsets) Option {
+	return func(options *options) {
+		options.clientsets = clientsets
 	}
-	return &client{
-		namespace: namespace,
-		config:    kubernetesConfig,
-		client:    kubernetesClient,
-		filter:    resource.NoFilter,
-	}, nil
+}
+
+// WithCache returns an option that serves Get and List requests from the given informer cache
+func WithCache(cache *resource.Cache) Option {
+	return func(options *options) {
+		options.cache = cache
+	}
+}
+
+// WithNamespaces returns an option that reads namespaced resources from the given namespaces in addition to the
+// client namespace
+func WithNamespaces(namespaces ...string) Option {
+	return func(options *options) {
+		options.namespaces = append(options.namespaces, namespaces...)
+	}
+}
+
+func newOptions(opts ...Option) options {
+	options := options{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// NewForNamespace returns a new Kubernetes client for the given namespace
+func NewForNamespace(namespace string, opts ...Option) (Client, error) {
+	return NewFiltered(namespace, resource.NoFilter, opts...)
 }
 
 // NewForNamespaceOrDie returns a new Kubernetes client for the given namespace
-func NewForNamespaceOrDie(namespace string) Client {
-	client, err := NewForNamespace(namespace)
+func NewForNamespaceOrDie(namespace string, opts ...Option) Client {
+	client, err := NewForNamespace(namespace, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -69,71 +114,126 @@ type Client interface {
 	// Namespace returns the client namespace
 	Namespace() string
 
+	// Namespaces returns the namespaces from which the client reads namespaced resources, starting with the client
+	// namespace. Cluster scoped resources are read cluster-wide.
+	Namespaces() []string
+
 	// Config returns the Kubernetes REST client configuration
 	Config() *rest.Config
 
 	// Clientset returns the client's Clientset
-	Clientset() *kubernetes.Clientset
+	Clientset() kubernetes.Interface
+
+	// DynamicClient returns the client's dynamic client
+	DynamicClient() kubedynamic.Interface
+
+	// RESTMapping returns the REST mapping for the given kind, refreshing discovery if the kind is unknown
+	RESTMapping(kind schema.GroupVersionKind) (*meta.RESTMapping, error)
+
+	// TypedClientset returns the client's typed clientset with the given name, creating it if necessary
+	TypedClientset(name string, newClientset resource.ClientsetFunc) (interface{}, error)
+
+	// Dynamic returns a reader and writer for unstructured objects of the given kind
+	Dynamic(kind schema.GroupVersionKind) dynamic.Client
+
+	// All lists the objects of every kind visible to the client
+	All() ([]*dynamic.Object, error)
 	AdmissionregistrationV1() admissionregistrationv1.Client
 	ApiextensionsV1() apiextensionsv1.Client
 	ApiextensionsV1beta1() apiextensionsv1beta1.Client
 	AppsV1() appsv1.Client
 	AppsV1beta1() appsv1beta1.Client
+	AutoscalingV1() autoscalingv1.Client
 	BatchV1() batchv1.Client
 	BatchV1beta1() batchv1beta1.Client
 	BatchV2alpha1() batchv2alpha1.Client
+	CoordinationV1() coordinationv1.Client
+	DiscoveryV1beta1() discoveryv1beta1.Client
 	ExtensionsV1beta1() extensionsv1beta1.Client
+	NetworkingV1() networkingv1.Client
 	NetworkingV1beta1() networkingv1beta1.Client
 	PolicyV1beta1() policyv1beta1.Client
 	RbacV1() rbacv1.Client
+	SchedulingV1() schedulingv1.Client
 	StorageV1() storagev1.Client
 	CoreV1() corev1.Client
 }
 
 // NewFiltered returns a new filtered Kubernetes client
-func NewFiltered(namespace string, filter resource.Filter) (Client, error) {
-	kubernetesConfig, err := config.GetRestConfig()
-	if err != nil {
-		return nil, err
-	}
-	kubernetesClient, err := kubernetes.NewForConfig(kubernetesConfig)
-	if err != nil {
-		return nil, err
+func NewFiltered(namespace string, filter resource.Filter, opts ...Option) (Client, error) {
+	options := newOptions(opts...)
+	clientsets := options.clientsets
+	if clientsets == nil {
+		kubernetesConfig, err := config.GetRestConfig()
+		if err != nil {
+			return nil, err
+		}
+		clientsets, err = resource.NewClientsets(kubernetesConfig, options.clientset)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return &client{
-		namespace: namespace,
-		config:    kubernetesConfig,
-		client:    kubernetesClient,
-		filter:    filter,
+		Clientsets: clientsets,
+		namespace:  namespace,
+		namespaces: getNamespaces(namespace, options.namespaces),
+		filter:     filter,
+		cache:      options.cache,
 	}, nil
 }
 
 // NewFilteredOrDie returns a new filtered Kubernetes client
-func NewFilteredOrDie(namespace string, filter resource.Filter) Client {
-	client, err := NewFiltered(namespace, filter)
+func NewFilteredOrDie(namespace string, filter resource.Filter, opts ...Option) Client {
+	client, err := NewFiltered(namespace, filter, opts...)
 	if err != nil {
 		panic(err)
 	}
 	return client
 }
 
+// getNamespaces returns the client namespace followed by each distinct additional namespace
+func getNamespaces(namespace string, additional []string) []string {
+	namespaces := []string{namespace}
+	for _, ns := range additional {
+		unique := ns != ""
+		for _, existing := range namespaces {
+			if ns == existing {
+				unique = false
+			}
+		}
+		if unique {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
 type client struct {
-	namespace string
-	config    *rest.Config
-	client    *kubernetes.Clientset
-	filter    resource.Filter
+	*resource.Clientsets
+	namespace  string
+	namespaces []string
+	filter     resource.Filter
+	cache      *resource.Cache
 }
 
 func (c *client) Namespace() string {
 	return c.namespace
 }
 
-func (c *client) Config() *rest.Config {
-	return c.config
+func (c *client) Namespaces() []string {
+	return c.namespaces
+}
+
+func (c *client) Cache() *resource.Cache {
+	return c.cache
 }
 
-func (c *client) Clientset() *kubernetes.Clientset {
-	return c.client
+func (c *client) Dynamic(kind schema.GroupVersionKind) dynamic.Client {
+	return dynamic.NewClient(c, c.filter, kind)
+}
+
+func (c *client) All() ([]*dynamic.Object, error) {
+	return dynamic.All(c, c.filter)
 }
 func (c *client) AdmissionregistrationV1() admissionregistrationv1.Client {
 	return admissionregistrationv1.NewClient(c, c.filter)
@@ -155,6 +255,10 @@ func (c *client) AppsV1beta1() appsv1beta1.Client {
 	return appsv1beta1.NewClient(c, c.filter)
 }
 
+func (c *client) AutoscalingV1() autoscalingv1.Client {
+	return autoscalingv1.NewClient(c, c.filter)
+}
+
 func (c *client) BatchV1() batchv1.Client {
 	return batchv1.NewClient(c, c.filter)
 }
@@ -167,10 +271,22 @@ func (c *client) BatchV2alpha1() batchv2alpha1.Client {
 	return batchv2alpha1.NewClient(c, c.filter)
 }
 
+func (c *client) CoordinationV1() coordinationv1.Client {
+	return coordinationv1.NewClient(c, c.filter)
+}
+
+func (c *client) DiscoveryV1beta1() discoveryv1beta1.Client {
+	return discoveryv1beta1.NewClient(c, c.filter)
+}
+
 func (c *client) ExtensionsV1beta1() extensionsv1beta1.Client {
 	return extensionsv1beta1.NewClient(c, c.filter)
 }
 
+func (c *client) NetworkingV1() networkingv1.Client {
+	return networkingv1.NewClient(c, c.filter)
+}
+
 func (c *client) NetworkingV1beta1() networkingv1beta1.Client {
 	return networkingv1beta1.NewClient(c, c.filter)
 }
@@ -183,6 +299,10 @@ func (c *client) RbacV1() rbacv1.Client {
 	return rbacv1.NewClient(c, c.filter)
 }
 
+func (c *client) SchedulingV1() schedulingv1.Client {
+	return schedulingv1.NewClient(c, c.filter)
+}
+
 func (c *client) StorageV1() storagev1.Client {
 	return storagev1.NewClient(c, c.filter)
 }
@@ -4,19 +4,25 @@ package v1
 
 import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 type Client interface {
 	ConfigMapsClient
 	EndpointsClient
+	EventsClient
+	LimitRangesClient
 	NamespacesClient
 	NodesClient
 	PersistentVolumesClient
 	PersistentVolumeClaimsClient
 	PodsClient
 	PodTemplatesClient
+	ResourceQuotasClient
 	SecretsClient
 	ServicesClient
+	ServiceAccountsClient
 }
 
 func NewClient(resources resource.Client, filter resource.Filter) Client {
@@ -24,14 +30,18 @@ func NewClient(resources resource.Client, filter resource.Filter) Client {
 		Client:                       resources,
 		ConfigMapsClient:             NewConfigMapsClient(resources, filter),
 		EndpointsClient:              NewEndpointsClient(resources, filter),
+		EventsClient:                 NewEventsClient(resources, filter),
+		LimitRangesClient:            NewLimitRangesClient(resources, filter),
 		NamespacesClient:             NewNamespacesClient(resources, filter),
 		NodesClient:                  NewNodesClient(resources, filter),
 		PersistentVolumesClient:      NewPersistentVolumesClient(resources, filter),
 		PersistentVolumeClaimsClient: NewPersistentVolumeClaimsClient(resources, filter),
 		PodsClient:                   NewPodsClient(resources, filter),
 		PodTemplatesClient:           NewPodTemplatesClient(resources, filter),
+		ResourceQuotasClient:         NewResourceQuotasClient(resources, filter),
 		SecretsClient:                NewSecretsClient(resources, filter),
 		ServicesClient:               NewServicesClient(resources, filter),
+		ServiceAccountsClient:        NewServiceAccountsClient(resources, filter),
 	}
 }
 
@@ -39,12 +49,28 @@ type client struct {
 	resource.Client
 	ConfigMapsClient
 	EndpointsClient
+	EventsClient
+	LimitRangesClient
 	NamespacesClient
 	NodesClient
 	PersistentVolumesClient
 	PersistentVolumeClaimsClient
 	PodsClient
 	PodTemplatesClient
+	ResourceQuotasClient
 	SecretsClient
 	ServicesClient
+	ServiceAccountsClient
+}
+
+func getClientset(client resource.Client) (kubernetes.Interface, error) {
+	return client.Clientset(), nil
+}
+
+func getRESTClient(client resource.Client) (rest.Interface, error) {
+	clientset, err := getClientset(client)
+	if err != nil {
+		return nil, err
+	}
+	return clientset.CoreV1().RESTClient(), nil
 }
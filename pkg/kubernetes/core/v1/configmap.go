@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var ConfigMapResource = resource.Type{
 }
 
 func NewConfigMap(configMap *corev1.ConfigMap, client resource.Client) *ConfigMap {
+	client = resource.ForNamespace(client, configMap.Namespace)
 	return &ConfigMap{
 		Resource: resource.NewResource(configMap.ObjectMeta, ConfigMapKind, client),
 		Object:   configMap,
@@ -35,12 +37,11 @@ type ConfigMap struct {
 }
 
 func (r *ConfigMap) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.CoreV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, ConfigMapKind.Scoped).
 		Resource(ConfigMapResource.Name).
@@ -50,3 +51,94 @@ func (r *ConfigMap) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *ConfigMap) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.ConfigMap{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, ConfigMapKind.Scoped).
+		Resource(ConfigMapResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *ConfigMap) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.ConfigMap{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, ConfigMapKind.Scoped).
+		Resource(ConfigMapResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *ConfigMap) Update(mutate func(*corev1.ConfigMap) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		configMap := r.Object.DeepCopy()
+		if err := mutate(configMap); err != nil {
+			return err
+		}
+		result := &corev1.ConfigMap{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, ConfigMapKind.Scoped).
+			Resource(ConfigMapResource.Name).
+			Name(r.Name).
+			Body(configMap).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *ConfigMap) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *ConfigMap) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *ConfigMap) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *ConfigMap) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
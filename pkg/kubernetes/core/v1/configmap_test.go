@@ -0,0 +1,134 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// newConfigMapServer returns a stand-in for the Kubernetes config map endpoint which stores a single config map.
+// The first update is rejected with a conflict to exercise retries.
+func newConfigMapServer(t *testing.T) (*httptest.Server, func() *corev1.ConfigMap) {
+	mu := &sync.Mutex{}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "test",
+			Name:            "foo",
+			ResourceVersion: "1",
+		},
+		Data: map[string]string{
+			"foo": "baz",
+		},
+	}
+	conflicted := false
+	write := func(w http.ResponseWriter, code int, object interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		assert.NoError(t, json.NewEncoder(w).Encode(object))
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if req.URL.Path != "/api/v1/namespaces/test/configmaps/foo" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch req.Method {
+		case http.MethodGet:
+			write(w, http.StatusOK, configMap)
+		case http.MethodPut:
+			update := &corev1.ConfigMap{}
+			assert.NoError(t, json.NewDecoder(req.Body).Decode(update))
+			if !conflicted || update.ResourceVersion != configMap.ResourceVersion {
+				conflicted = true
+				version, _ := strconv.Atoi(configMap.ResourceVersion)
+				configMap.ResourceVersion = strconv.Itoa(version + 1)
+				err := errors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "foo", nil)
+				write(w, http.StatusConflict, err.Status())
+				return
+			}
+			version, _ := strconv.Atoi(update.ResourceVersion)
+			update.ResourceVersion = strconv.Itoa(version + 1)
+			configMap = update
+			write(w, http.StatusOK, configMap)
+		case http.MethodPatch:
+			assert.Equal(t, string(types.MergePatchType), req.Header.Get("Content-Type"))
+			data, err := ioutil.ReadAll(req.Body)
+			assert.NoError(t, err)
+			patch := &corev1.ConfigMap{}
+			assert.NoError(t, json.Unmarshal(data, patch))
+			if configMap.Labels == nil {
+				configMap.Labels = make(map[string]string)
+			}
+			for key, value := range patch.Labels {
+				configMap.Labels[key] = value
+			}
+			write(w, http.StatusOK, configMap)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	return server, func() *corev1.ConfigMap {
+		mu.Lock()
+		defer mu.Unlock()
+		return configMap.DeepCopy()
+	}
+}
+
+func TestConfigMapUpdate(t *testing.T) {
+	server, get := newConfigMapServer(t)
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	configMap := NewConfigMap(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "foo",
+		},
+	}, client)
+
+	calls := 0
+	err := configMap.Update(func(configMap *corev1.ConfigMap) error {
+		calls++
+		configMap.Data["foo"] = "bar"
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "bar", configMap.Object.Data["foo"])
+	assert.Equal(t, "bar", get().Data["foo"])
+
+	err = configMap.SetLabel("app", "onos")
+	assert.NoError(t, err)
+	assert.Equal(t, "onos", configMap.Object.Labels["app"])
+	assert.Equal(t, "onos", get().Labels["app"])
+
+	err = configMap.Refresh()
+	assert.NoError(t, err)
+	assert.Equal(t, get().ResourceVersion, configMap.Object.ResourceVersion)
+	assert.Equal(t, get().Data, configMap.Object.Data)
+}
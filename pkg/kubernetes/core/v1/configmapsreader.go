@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type ConfigMapsReader interface {
 	Get(name string) (*ConfigMap, error)
-	List() ([]*ConfigMap, error)
+	List(opts ...resource.ListOption) ([]*ConfigMap, error)
+	ListPages(handler func([]*ConfigMap) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan ConfigMapEvent, error)
+	WaitFor(ctx context.Context, predicate func(ConfigMapEvent) (bool, error)) (ConfigMapEvent, error)
+}
+
+type ConfigMapEvent struct {
+	Type      resource.EventType
+	ConfigMap *ConfigMap
 }
 
 func NewConfigMapsReader(client resource.Client, filter resource.Filter) ConfigMapsReader {
@@ -30,24 +43,13 @@ type configMapsReader struct {
 }
 
 func (c *configMapsReader) Get(name string) (*ConfigMap, error) {
-	configMap := &corev1.ConfigMap{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.CoreV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), ConfigMapKind.Scoped).
-		Resource(ConfigMapResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(configMap)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, ConfigMapKind.Scoped) {
+		configMap, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   ConfigMapKind.Group,
 			Version: ConfigMapKind.Version,
@@ -55,37 +57,123 @@ func (c *configMapsReader) Get(name string) (*ConfigMap, error) {
 		}, configMap.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewConfigMap(configMap, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    ConfigMapKind.Group,
+		Resource: ConfigMapResource.Name,
+	}, name)
+}
+
+func (c *configMapsReader) get(namespace, name string) (*corev1.ConfigMap, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, ConfigMapKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    ConfigMapKind.Group,
 				Resource: ConfigMapResource.Name,
 			}, name)
 		}
+		return object.(*corev1.ConfigMap).DeepCopy(), nil
 	}
-	return NewConfigMap(configMap, c.Client), nil
-}
 
-func (c *configMapsReader) List() ([]*ConfigMap, error) {
-	list := &corev1.ConfigMapList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &corev1.ConfigMap{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.CoreV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), ConfigMapKind.Scoped).
+		NamespaceIfScoped(namespace, ConfigMapKind.Scoped).
 		Resource(ConfigMapResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*ConfigMap, 0, len(list.Items))
-	for _, configMap := range list.Items {
+func (c *configMapsReader) List(opts ...resource.ListOption) ([]*ConfigMap, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, ConfigMapKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*ConfigMap, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *configMapsReader) ListPages(handler func([]*ConfigMap) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, ConfigMapKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *configMapsReader) list(namespace string, options metav1.ListOptions) ([]*ConfigMap, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *configMapsReader) filterItems(items []corev1.ConfigMap) ([]*ConfigMap, error) {
+	results := make([]*ConfigMap, 0, len(items))
+	for _, configMap := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   ConfigMapKind.Group,
 			Version: ConfigMapKind.Version,
@@ -100,3 +188,145 @@ func (c *configMapsReader) List() ([]*ConfigMap, error) {
 	}
 	return results, nil
 }
+
+func (c *configMapsReader) listItems(namespace string, options metav1.ListOptions) ([]corev1.ConfigMap, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]corev1.ConfigMap, 0, len(objects))
+			for _, object := range objects {
+				configMap := object.(*corev1.ConfigMap)
+				if selector.Matches(labels.Set(configMap.Labels)) {
+					items = append(items, *configMap.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &corev1.ConfigMapList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, ConfigMapKind.Scoped).
+		Resource(ConfigMapResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *configMapsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(ConfigMapKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &corev1.ConfigMapList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, ConfigMapKind.Scoped).
+					Resource(ConfigMapResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, ConfigMapKind.Scoped).
+					Resource(ConfigMapResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &corev1.ConfigMap{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *configMapsReader) Watch(ctx context.Context) (<-chan ConfigMapEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, ConfigMapKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, ConfigMapKind.Scoped).
+			Resource(ConfigMapResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ConfigMapEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			configMap, ok := watchEvent.Object.(*corev1.ConfigMap)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   ConfigMapKind.Group,
+				Version: ConfigMapKind.Version,
+				Kind:    ConfigMapKind.Kind,
+			}, configMap.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- ConfigMapEvent{Type: watchEvent.Type, ConfigMap: NewConfigMap(configMap, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *configMapsReader) WaitFor(ctx context.Context, predicate func(ConfigMapEvent) (bool, error)) (ConfigMapEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return ConfigMapEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return ConfigMapEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return ConfigMapEvent{}, ctx.Err()
+}
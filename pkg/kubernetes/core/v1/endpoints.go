@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var EndpointsResource = resource.Type{
 }
 
 func NewEndpoints(endpoints *corev1.Endpoints, client resource.Client) *Endpoints {
+	client = resource.ForNamespace(client, endpoints.Namespace)
 	return &Endpoints{
 		Resource: resource.NewResource(endpoints.ObjectMeta, EndpointsKind, client),
 		Object:   endpoints,
@@ -35,12 +37,11 @@ type Endpoints struct {
 }
 
 func (r *Endpoints) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.CoreV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, EndpointsKind.Scoped).
 		Resource(EndpointsResource.Name).
@@ -50,3 +51,94 @@ func (r *Endpoints) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *Endpoints) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.Endpoints{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, EndpointsKind.Scoped).
+		Resource(EndpointsResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Endpoints) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.Endpoints{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, EndpointsKind.Scoped).
+		Resource(EndpointsResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Endpoints) Update(mutate func(*corev1.Endpoints) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		endpoints := r.Object.DeepCopy()
+		if err := mutate(endpoints); err != nil {
+			return err
+		}
+		result := &corev1.Endpoints{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, EndpointsKind.Scoped).
+			Resource(EndpointsResource.Name).
+			Name(r.Name).
+			Body(endpoints).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *Endpoints) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *Endpoints) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *Endpoints) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *Endpoints) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type EndpointsReader interface {
 	Get(name string) (*Endpoints, error)
-	List() ([]*Endpoints, error)
+	List(opts ...resource.ListOption) ([]*Endpoints, error)
+	ListPages(handler func([]*Endpoints) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan EndpointsEvent, error)
+	WaitFor(ctx context.Context, predicate func(EndpointsEvent) (bool, error)) (EndpointsEvent, error)
+}
+
+type EndpointsEvent struct {
+	Type      resource.EventType
+	Endpoints *Endpoints
 }
 
 func NewEndpointsReader(client resource.Client, filter resource.Filter) EndpointsReader {
@@ -30,24 +43,13 @@ type endpointsReader struct {
 }
 
 func (c *endpointsReader) Get(name string) (*Endpoints, error) {
-	endpoints := &corev1.Endpoints{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.CoreV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), EndpointsKind.Scoped).
-		Resource(EndpointsResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(endpoints)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, EndpointsKind.Scoped) {
+		endpoints, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   EndpointsKind.Group,
 			Version: EndpointsKind.Version,
@@ -55,37 +57,123 @@ func (c *endpointsReader) Get(name string) (*Endpoints, error) {
 		}, endpoints.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewEndpoints(endpoints, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    EndpointsKind.Group,
+		Resource: EndpointsResource.Name,
+	}, name)
+}
+
+func (c *endpointsReader) get(namespace, name string) (*corev1.Endpoints, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, EndpointsKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    EndpointsKind.Group,
 				Resource: EndpointsResource.Name,
 			}, name)
 		}
+		return object.(*corev1.Endpoints).DeepCopy(), nil
 	}
-	return NewEndpoints(endpoints, c.Client), nil
-}
 
-func (c *endpointsReader) List() ([]*Endpoints, error) {
-	list := &corev1.EndpointsList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &corev1.Endpoints{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.CoreV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), EndpointsKind.Scoped).
+		NamespaceIfScoped(namespace, EndpointsKind.Scoped).
 		Resource(EndpointsResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*Endpoints, 0, len(list.Items))
-	for _, endpoints := range list.Items {
+func (c *endpointsReader) List(opts ...resource.ListOption) ([]*Endpoints, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, EndpointsKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*Endpoints, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *endpointsReader) ListPages(handler func([]*Endpoints) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, EndpointsKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *endpointsReader) list(namespace string, options metav1.ListOptions) ([]*Endpoints, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *endpointsReader) filterItems(items []corev1.Endpoints) ([]*Endpoints, error) {
+	results := make([]*Endpoints, 0, len(items))
+	for _, endpoints := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   EndpointsKind.Group,
 			Version: EndpointsKind.Version,
@@ -100,3 +188,145 @@ func (c *endpointsReader) List() ([]*Endpoints, error) {
 	}
 	return results, nil
 }
+
+func (c *endpointsReader) listItems(namespace string, options metav1.ListOptions) ([]corev1.Endpoints, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]corev1.Endpoints, 0, len(objects))
+			for _, object := range objects {
+				endpoints := object.(*corev1.Endpoints)
+				if selector.Matches(labels.Set(endpoints.Labels)) {
+					items = append(items, *endpoints.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &corev1.EndpointsList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, EndpointsKind.Scoped).
+		Resource(EndpointsResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *endpointsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(EndpointsKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &corev1.EndpointsList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, EndpointsKind.Scoped).
+					Resource(EndpointsResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, EndpointsKind.Scoped).
+					Resource(EndpointsResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &corev1.Endpoints{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *endpointsReader) Watch(ctx context.Context) (<-chan EndpointsEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, EndpointsKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, EndpointsKind.Scoped).
+			Resource(EndpointsResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan EndpointsEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			endpoints, ok := watchEvent.Object.(*corev1.Endpoints)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   EndpointsKind.Group,
+				Version: EndpointsKind.Version,
+				Kind:    EndpointsKind.Kind,
+			}, endpoints.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- EndpointsEvent{Type: watchEvent.Type, Endpoints: NewEndpoints(endpoints, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *endpointsReader) WaitFor(ctx context.Context, predicate func(EndpointsEvent) (bool, error)) (EndpointsEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return EndpointsEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return EndpointsEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return EndpointsEvent{}, ctx.Err()
+}
@@ -0,0 +1,133 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+	"k8s.io/client-go/util/exec"
+	"net/http"
+	"sync"
+)
+
+// ExitError is returned by Exec when the command exits with a non-zero exit code
+type ExitError struct {
+	// Code is the exit code of the command
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("command terminated with exit code %d", e.Code)
+}
+
+// ExitStatus returns the exit code of the command
+func (e *ExitError) ExitStatus() int {
+	return e.Code
+}
+
+// Exec executes a command in a container of the pod, streaming the given stdin to the command and its output to
+// the given stdout and stderr. Any of the streams may be nil. If the command exits with a non-zero exit code,
+// an *ExitError is returned. If the context is done before the command exits, the connection to the pod is closed
+// and the context's error is returned. Closing the connection closes the command's streams but does not kill the
+// command: a command that does not exit when its stdin is closed keeps running in the container.
+func (r *Pod) Exec(ctx context.Context, container string, command []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	request := r.Clientset().CoreV1().
+		RESTClient().
+		Post().
+		Namespace(r.Namespace).
+		Resource(PodResource.Name).
+		Name(r.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    stdout != nil,
+			Stderr:    stderr != nil,
+		}, scheme.ParameterCodec)
+
+	transport, upgrader, err := spdy.RoundTripperFor(r.Config())
+	if err != nil {
+		return err
+	}
+	execUpgrader := &execUpgrader{Upgrader: upgrader}
+	executor, err := remotecommand.NewSPDYExecutorForTransports(transport, execUpgrader, http.MethodPost, request.URL())
+	if err != nil {
+		return err
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- executor.Stream(remotecommand.StreamOptions{
+			Stdin:  stdin,
+			Stdout: stdout,
+			Stderr: stderr,
+		})
+	}()
+
+	select {
+	case err := <-errCh:
+		if exitErr, ok := err.(exec.CodeExitError); ok {
+			return &ExitError{
+				Code: exitErr.Code,
+			}
+		}
+		return err
+	case <-ctx.Done():
+		// Closing the connection ends the stream, so the streaming goroutine exits
+		execUpgrader.close()
+		return ctx.Err()
+	}
+}
+
+// execUpgrader is an upgrader that tracks the connection to the pod so it can be closed when an exec is cancelled
+type execUpgrader struct {
+	spdy.Upgrader
+	conn   httpstream.Connection
+	closed bool
+	mu     sync.Mutex
+}
+
+func (u *execUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	conn, err := u.Upgrader.NewConnection(resp)
+	if err != nil {
+		return nil, err
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.closed {
+		_ = conn.Close()
+		return nil, errors.New("exec cancelled")
+	}
+	u.conn = conn
+	return conn, nil
+}
+
+// close closes the connection to the pod, or the connection once it is created if the upgrade is in progress
+func (u *execUpgrader) close() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.closed = true
+	if u.conn != nil {
+		_ = u.conn.Close()
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/httpstream/spdy"
+	remotecommandconsts "k8s.io/apimachinery/pkg/util/remotecommand"
+	"k8s.io/client-go/rest"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testClient struct {
+	*resource.Clientsets
+	namespace string
+}
+
+func (c *testClient) Namespace() string {
+	return c.namespace
+}
+
+func newTestClient(t *testing.T, server *httptest.Server) *testClient {
+	config := &rest.Config{
+		Host: server.URL,
+	}
+	clientsets, err := resource.NewClientsets(config, nil)
+	assert.NoError(t, err)
+	return &testClient{
+		Clientsets: clientsets,
+		namespace:  "test",
+	}
+}
+
+var _ resource.Client = &testClient{}
+
+// newExecServer returns a stand-in for the Kubernetes exec endpoint. The server supports four commands:
+// 'echo' writes its arguments to stdout, 'cat' copies stdin to stdout, 'exit' exits with the given code, and
+// 'sleep' blocks until the connection is closed, which is signalled on the given channel.
+func newExecServer(t *testing.T, closed ...chan<- struct{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !strings.HasSuffix(req.URL.Path, "/namespaces/test/pods/onos-0/exec") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		query := req.URL.Query()
+		expected := 1
+		for _, stream := range []string{"stdin", "stdout", "stderr"} {
+			if query.Get(stream) == "true" {
+				expected++
+			}
+		}
+
+		if _, err := httpstream.Handshake(req, w, []string{remotecommandconsts.StreamProtocolV4Name}); err != nil {
+			return
+		}
+
+		streamCh := make(chan httpstream.Stream, expected)
+		conn := spdy.NewResponseUpgrader().UpgradeResponse(w, req, func(stream httpstream.Stream, replySent <-chan struct{}) error {
+			streamCh <- stream
+			return nil
+		})
+		if conn == nil {
+			return
+		}
+		defer conn.Close()
+
+		streams := make(map[string]httpstream.Stream)
+		for len(streams) < expected {
+			select {
+			case stream := <-streamCh:
+				streams[stream.Headers().Get(corev1.StreamType)] = stream
+			case <-time.After(10 * time.Second):
+				t.Error("timed out waiting for streams")
+				return
+			}
+		}
+
+		command := query["command"]
+		code := 0
+		switch command[0] {
+		case "echo":
+			_, _ = streams[corev1.StreamTypeStdout].Write([]byte(strings.Join(command[1:], " ") + "\n"))
+		case "cat":
+			_, _ = io.Copy(streams[corev1.StreamTypeStdout], streams[corev1.StreamTypeStdin])
+		case "exit":
+			code, _ = strconv.Atoi(command[1])
+		case "sleep":
+			select {
+			case <-conn.CloseChan():
+				for _, ch := range closed {
+					close(ch)
+				}
+			case <-time.After(10 * time.Second):
+				t.Error("timed out waiting for the connection to close")
+			}
+			return
+		}
+
+		status := metav1.Status{
+			Status: metav1.StatusSuccess,
+		}
+		if code != 0 {
+			status = metav1.Status{
+				Status: metav1.StatusFailure,
+				Reason: remotecommandconsts.NonZeroExitCodeReason,
+				Details: &metav1.StatusDetails{
+					Causes: []metav1.StatusCause{
+						{
+							Type:    remotecommandconsts.ExitCodeCauseType,
+							Message: strconv.Itoa(code),
+						},
+					},
+				},
+			}
+		}
+		bytes, _ := json.Marshal(status)
+		_, _ = streams[corev1.StreamTypeError].Write(bytes)
+		for _, stream := range streams {
+			_ = stream.Close()
+		}
+	}))
+}
+
+func newTestPod(t *testing.T, server *httptest.Server) *Pod {
+	client := newTestClient(t, server)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "onos-0",
+		},
+	}
+	return NewPod(pod, client)
+}
+
+func TestPodExec(t *testing.T) {
+	server := newExecServer(t)
+	defer server.Close()
+	pod := newTestPod(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	stdout := &bytes.Buffer{}
+	err := pod.Exec(ctx, "onos", []string{"echo", "hello", "world"}, nil, stdout, ioutil.Discard)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world\n", stdout.String())
+
+	stdout = &bytes.Buffer{}
+	err = pod.Exec(ctx, "onos", []string{"cat"}, strings.NewReader("foo"), stdout, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", stdout.String())
+
+	err = pod.Exec(ctx, "onos", []string{"exit", "3"}, nil, ioutil.Discard, ioutil.Discard)
+	assert.Error(t, err)
+	exitErr, ok := err.(*ExitError)
+	assert.True(t, ok)
+	assert.Equal(t, 3, exitErr.Code)
+}
+
+func TestPodExecCancel(t *testing.T) {
+	closed := make(chan struct{})
+	server := newExecServer(t, closed)
+	defer server.Close()
+	pod := newTestPod(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := pod.Exec(ctx, "onos", []string{"sleep"}, nil, ioutil.Discard, nil)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// The connection to the pod is closed when the context is done
+	select {
+	case <-closed:
+	case <-time.After(10 * time.Second):
+		t.Error("connection not closed")
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"time"
+)
+
+// LogOptions are options for reading pod logs
+type LogOptions struct {
+	// Container is the container from which to read logs. Required if the pod has more than one container.
+	Container string
+	// Previous reads the logs of the previous terminated container
+	Previous bool
+	// Since reads only logs newer than the given duration
+	Since time.Duration
+	// TailLines reads only the given number of lines from the end of the logs
+	TailLines int64
+	// Timestamps prefixes each line with its timestamp
+	Timestamps bool
+	// Follow streams logs until the reader is closed or the container terminates
+	Follow bool
+}
+
+// Logs returns a stream of the pod's logs
+func (r *Pod) Logs(options LogOptions) (io.ReadCloser, error) {
+	return r.Clientset().CoreV1().Pods(r.Namespace).GetLogs(r.Name, getPodLogOptions(options)).Stream()
+}
+
+func getPodLogOptions(options LogOptions) *corev1.PodLogOptions {
+	logOptions := &corev1.PodLogOptions{
+		Container:  options.Container,
+		Previous:   options.Previous,
+		Timestamps: options.Timestamps,
+		Follow:     options.Follow,
+	}
+	if options.Since > 0 {
+		seconds := int64(options.Since.Round(time.Second).Seconds())
+		if seconds == 0 {
+			seconds = 1
+		}
+		logOptions.SinceSeconds = &seconds
+	}
+	if options.TailLines > 0 {
+		tailLines := options.TailLines
+		logOptions.TailLines = &tailLines
+	}
+	return logOptions
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var NamespaceResource = resource.Type{
 }
 
 func NewNamespace(namespace *corev1.Namespace, client resource.Client) *Namespace {
+	client = resource.ForNamespace(client, namespace.Namespace)
 	return &Namespace{
 		Resource: resource.NewResource(namespace.ObjectMeta, NamespaceKind, client),
 		Object:   namespace,
@@ -35,12 +37,11 @@ type Namespace struct {
 }
 
 func (r *Namespace) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.CoreV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, NamespaceKind.Scoped).
 		Resource(NamespaceResource.Name).
@@ -50,3 +51,94 @@ func (r *Namespace) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *Namespace) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.Namespace{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, NamespaceKind.Scoped).
+		Resource(NamespaceResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Namespace) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.Namespace{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, NamespaceKind.Scoped).
+		Resource(NamespaceResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Namespace) Update(mutate func(*corev1.Namespace) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		namespace := r.Object.DeepCopy()
+		if err := mutate(namespace); err != nil {
+			return err
+		}
+		result := &corev1.Namespace{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, NamespaceKind.Scoped).
+			Resource(NamespaceResource.Name).
+			Name(r.Name).
+			Body(namespace).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *Namespace) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *Namespace) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *Namespace) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *Namespace) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type NamespacesReader interface {
 	Get(name string) (*Namespace, error)
-	List() ([]*Namespace, error)
+	List(opts ...resource.ListOption) ([]*Namespace, error)
+	ListPages(handler func([]*Namespace) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan NamespaceEvent, error)
+	WaitFor(ctx context.Context, predicate func(NamespaceEvent) (bool, error)) (NamespaceEvent, error)
+}
+
+type NamespaceEvent struct {
+	Type      resource.EventType
+	Namespace *Namespace
 }
 
 func NewNamespacesReader(client resource.Client, filter resource.Filter) NamespacesReader {
@@ -30,24 +43,13 @@ type namespacesReader struct {
 }
 
 func (c *namespacesReader) Get(name string) (*Namespace, error) {
-	namespace := &corev1.Namespace{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.CoreV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), NamespaceKind.Scoped).
-		Resource(NamespaceResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(namespace)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, NamespaceKind.Scoped) {
+		namespace, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   NamespaceKind.Group,
 			Version: NamespaceKind.Version,
@@ -55,37 +57,123 @@ func (c *namespacesReader) Get(name string) (*Namespace, error) {
 		}, namespace.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewNamespace(namespace, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    NamespaceKind.Group,
+		Resource: NamespaceResource.Name,
+	}, name)
+}
+
+func (c *namespacesReader) get(namespace, name string) (*corev1.Namespace, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, NamespaceKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    NamespaceKind.Group,
 				Resource: NamespaceResource.Name,
 			}, name)
 		}
+		return object.(*corev1.Namespace).DeepCopy(), nil
 	}
-	return NewNamespace(namespace, c.Client), nil
-}
 
-func (c *namespacesReader) List() ([]*Namespace, error) {
-	list := &corev1.NamespaceList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &corev1.Namespace{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.CoreV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), NamespaceKind.Scoped).
+		NamespaceIfScoped(namespace, NamespaceKind.Scoped).
 		Resource(NamespaceResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*Namespace, 0, len(list.Items))
-	for _, namespace := range list.Items {
+func (c *namespacesReader) List(opts ...resource.ListOption) ([]*Namespace, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, NamespaceKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*Namespace, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *namespacesReader) ListPages(handler func([]*Namespace) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, NamespaceKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *namespacesReader) list(namespace string, options metav1.ListOptions) ([]*Namespace, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *namespacesReader) filterItems(items []corev1.Namespace) ([]*Namespace, error) {
+	results := make([]*Namespace, 0, len(items))
+	for _, namespace := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   NamespaceKind.Group,
 			Version: NamespaceKind.Version,
@@ -100,3 +188,145 @@ func (c *namespacesReader) List() ([]*Namespace, error) {
 	}
 	return results, nil
 }
+
+func (c *namespacesReader) listItems(namespace string, options metav1.ListOptions) ([]corev1.Namespace, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]corev1.Namespace, 0, len(objects))
+			for _, object := range objects {
+				namespace := object.(*corev1.Namespace)
+				if selector.Matches(labels.Set(namespace.Labels)) {
+					items = append(items, *namespace.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &corev1.NamespaceList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, NamespaceKind.Scoped).
+		Resource(NamespaceResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *namespacesReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(NamespaceKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &corev1.NamespaceList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, NamespaceKind.Scoped).
+					Resource(NamespaceResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, NamespaceKind.Scoped).
+					Resource(NamespaceResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &corev1.Namespace{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *namespacesReader) Watch(ctx context.Context) (<-chan NamespaceEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, NamespaceKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, NamespaceKind.Scoped).
+			Resource(NamespaceResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan NamespaceEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			namespace, ok := watchEvent.Object.(*corev1.Namespace)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   NamespaceKind.Group,
+				Version: NamespaceKind.Version,
+				Kind:    NamespaceKind.Kind,
+			}, namespace.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- NamespaceEvent{Type: watchEvent.Type, Namespace: NewNamespace(namespace, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *namespacesReader) WaitFor(ctx context.Context, predicate func(NamespaceEvent) (bool, error)) (NamespaceEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return NamespaceEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return NamespaceEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return NamespaceEvent{}, ctx.Err()
+}
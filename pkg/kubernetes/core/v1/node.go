@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var NodeResource = resource.Type{
 }
 
 func NewNode(node *corev1.Node, client resource.Client) *Node {
+	client = resource.ForNamespace(client, node.Namespace)
 	return &Node{
 		Resource: resource.NewResource(node.ObjectMeta, NodeKind, client),
 		Object:   node,
@@ -35,12 +37,11 @@ type Node struct {
 }
 
 func (r *Node) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.CoreV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, NodeKind.Scoped).
 		Resource(NodeResource.Name).
@@ -50,3 +51,94 @@ func (r *Node) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *Node) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.Node{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, NodeKind.Scoped).
+		Resource(NodeResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Node) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.Node{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, NodeKind.Scoped).
+		Resource(NodeResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Node) Update(mutate func(*corev1.Node) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		node := r.Object.DeepCopy()
+		if err := mutate(node); err != nil {
+			return err
+		}
+		result := &corev1.Node{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, NodeKind.Scoped).
+			Resource(NodeResource.Name).
+			Name(r.Name).
+			Body(node).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *Node) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *Node) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *Node) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *Node) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
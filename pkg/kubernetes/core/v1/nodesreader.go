@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type NodesReader interface {
 	Get(name string) (*Node, error)
-	List() ([]*Node, error)
+	List(opts ...resource.ListOption) ([]*Node, error)
+	ListPages(handler func([]*Node) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan NodeEvent, error)
+	WaitFor(ctx context.Context, predicate func(NodeEvent) (bool, error)) (NodeEvent, error)
+}
+
+type NodeEvent struct {
+	Type resource.EventType
+	Node *Node
 }
 
 func NewNodesReader(client resource.Client, filter resource.Filter) NodesReader {
@@ -30,24 +43,13 @@ type nodesReader struct {
 }
 
 func (c *nodesReader) Get(name string) (*Node, error) {
-	node := &corev1.Node{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.CoreV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), NodeKind.Scoped).
-		Resource(NodeResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(node)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, NodeKind.Scoped) {
+		node, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   NodeKind.Group,
 			Version: NodeKind.Version,
@@ -55,37 +57,123 @@ func (c *nodesReader) Get(name string) (*Node, error) {
 		}, node.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewNode(node, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    NodeKind.Group,
+		Resource: NodeResource.Name,
+	}, name)
+}
+
+func (c *nodesReader) get(namespace, name string) (*corev1.Node, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, NodeKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    NodeKind.Group,
 				Resource: NodeResource.Name,
 			}, name)
 		}
+		return object.(*corev1.Node).DeepCopy(), nil
 	}
-	return NewNode(node, c.Client), nil
-}
 
-func (c *nodesReader) List() ([]*Node, error) {
-	list := &corev1.NodeList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &corev1.Node{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.CoreV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), NodeKind.Scoped).
+		NamespaceIfScoped(namespace, NodeKind.Scoped).
 		Resource(NodeResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*Node, 0, len(list.Items))
-	for _, node := range list.Items {
+func (c *nodesReader) List(opts ...resource.ListOption) ([]*Node, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, NodeKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*Node, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *nodesReader) ListPages(handler func([]*Node) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, NodeKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *nodesReader) list(namespace string, options metav1.ListOptions) ([]*Node, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *nodesReader) filterItems(items []corev1.Node) ([]*Node, error) {
+	results := make([]*Node, 0, len(items))
+	for _, node := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   NodeKind.Group,
 			Version: NodeKind.Version,
@@ -100,3 +188,145 @@ func (c *nodesReader) List() ([]*Node, error) {
 	}
 	return results, nil
 }
+
+func (c *nodesReader) listItems(namespace string, options metav1.ListOptions) ([]corev1.Node, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]corev1.Node, 0, len(objects))
+			for _, object := range objects {
+				node := object.(*corev1.Node)
+				if selector.Matches(labels.Set(node.Labels)) {
+					items = append(items, *node.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &corev1.NodeList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, NodeKind.Scoped).
+		Resource(NodeResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *nodesReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(NodeKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &corev1.NodeList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, NodeKind.Scoped).
+					Resource(NodeResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, NodeKind.Scoped).
+					Resource(NodeResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &corev1.Node{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *nodesReader) Watch(ctx context.Context) (<-chan NodeEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, NodeKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, NodeKind.Scoped).
+			Resource(NodeResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan NodeEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			node, ok := watchEvent.Object.(*corev1.Node)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   NodeKind.Group,
+				Version: NodeKind.Version,
+				Kind:    NodeKind.Kind,
+			}, node.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- NodeEvent{Type: watchEvent.Type, Node: NewNode(node, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *nodesReader) WaitFor(ctx context.Context, predicate func(NodeEvent) (bool, error)) (NodeEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return NodeEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return NodeEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return NodeEvent{}, ctx.Err()
+}
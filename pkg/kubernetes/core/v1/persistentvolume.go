@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -14,7 +15,7 @@ var PersistentVolumeKind = resource.Kind{
 	Group:   "",
 	Version: "v1",
 	Kind:    "PersistentVolume",
-	Scoped:  true,
+	Scoped:  false,
 }
 
 var PersistentVolumeResource = resource.Type{
@@ -23,6 +24,7 @@ var PersistentVolumeResource = resource.Type{
 }
 
 func NewPersistentVolume(persistentVolume *corev1.PersistentVolume, client resource.Client) *PersistentVolume {
+	client = resource.ForNamespace(client, persistentVolume.Namespace)
 	return &PersistentVolume{
 		Resource: resource.NewResource(persistentVolume.ObjectMeta, PersistentVolumeKind, client),
 		Object:   persistentVolume,
@@ -35,12 +37,11 @@ type PersistentVolume struct {
 }
 
 func (r *PersistentVolume) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.CoreV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, PersistentVolumeKind.Scoped).
 		Resource(PersistentVolumeResource.Name).
@@ -50,3 +51,94 @@ func (r *PersistentVolume) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *PersistentVolume) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.PersistentVolume{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, PersistentVolumeKind.Scoped).
+		Resource(PersistentVolumeResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *PersistentVolume) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.PersistentVolume{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, PersistentVolumeKind.Scoped).
+		Resource(PersistentVolumeResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *PersistentVolume) Update(mutate func(*corev1.PersistentVolume) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		persistentVolume := r.Object.DeepCopy()
+		if err := mutate(persistentVolume); err != nil {
+			return err
+		}
+		result := &corev1.PersistentVolume{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, PersistentVolumeKind.Scoped).
+			Resource(PersistentVolumeResource.Name).
+			Name(r.Name).
+			Body(persistentVolume).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *PersistentVolume) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *PersistentVolume) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *PersistentVolume) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *PersistentVolume) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var PersistentVolumeClaimResource = resource.Type{
 }
 
 func NewPersistentVolumeClaim(persistentVolumeClaim *corev1.PersistentVolumeClaim, client resource.Client) *PersistentVolumeClaim {
+	client = resource.ForNamespace(client, persistentVolumeClaim.Namespace)
 	return &PersistentVolumeClaim{
 		Resource: resource.NewResource(persistentVolumeClaim.ObjectMeta, PersistentVolumeClaimKind, client),
 		Object:   persistentVolumeClaim,
@@ -35,12 +37,11 @@ type PersistentVolumeClaim struct {
 }
 
 func (r *PersistentVolumeClaim) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.CoreV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, PersistentVolumeClaimKind.Scoped).
 		Resource(PersistentVolumeClaimResource.Name).
@@ -50,3 +51,94 @@ func (r *PersistentVolumeClaim) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *PersistentVolumeClaim) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.PersistentVolumeClaim{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, PersistentVolumeClaimKind.Scoped).
+		Resource(PersistentVolumeClaimResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *PersistentVolumeClaim) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.PersistentVolumeClaim{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, PersistentVolumeClaimKind.Scoped).
+		Resource(PersistentVolumeClaimResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *PersistentVolumeClaim) Update(mutate func(*corev1.PersistentVolumeClaim) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		persistentVolumeClaim := r.Object.DeepCopy()
+		if err := mutate(persistentVolumeClaim); err != nil {
+			return err
+		}
+		result := &corev1.PersistentVolumeClaim{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, PersistentVolumeClaimKind.Scoped).
+			Resource(PersistentVolumeClaimResource.Name).
+			Name(r.Name).
+			Body(persistentVolumeClaim).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *PersistentVolumeClaim) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *PersistentVolumeClaim) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *PersistentVolumeClaim) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *PersistentVolumeClaim) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type PersistentVolumeClaimsReader interface {
 	Get(name string) (*PersistentVolumeClaim, error)
-	List() ([]*PersistentVolumeClaim, error)
+	List(opts ...resource.ListOption) ([]*PersistentVolumeClaim, error)
+	ListPages(handler func([]*PersistentVolumeClaim) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan PersistentVolumeClaimEvent, error)
+	WaitFor(ctx context.Context, predicate func(PersistentVolumeClaimEvent) (bool, error)) (PersistentVolumeClaimEvent, error)
+}
+
+type PersistentVolumeClaimEvent struct {
+	Type                  resource.EventType
+	PersistentVolumeClaim *PersistentVolumeClaim
 }
 
 func NewPersistentVolumeClaimsReader(client resource.Client, filter resource.Filter) PersistentVolumeClaimsReader {
@@ -30,24 +43,13 @@ type persistentVolumeClaimsReader struct {
 }
 
 func (c *persistentVolumeClaimsReader) Get(name string) (*PersistentVolumeClaim, error) {
-	persistentVolumeClaim := &corev1.PersistentVolumeClaim{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.CoreV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), PersistentVolumeClaimKind.Scoped).
-		Resource(PersistentVolumeClaimResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(persistentVolumeClaim)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, PersistentVolumeClaimKind.Scoped) {
+		persistentVolumeClaim, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   PersistentVolumeClaimKind.Group,
 			Version: PersistentVolumeClaimKind.Version,
@@ -55,37 +57,123 @@ func (c *persistentVolumeClaimsReader) Get(name string) (*PersistentVolumeClaim,
 		}, persistentVolumeClaim.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewPersistentVolumeClaim(persistentVolumeClaim, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    PersistentVolumeClaimKind.Group,
+		Resource: PersistentVolumeClaimResource.Name,
+	}, name)
+}
+
+func (c *persistentVolumeClaimsReader) get(namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, PersistentVolumeClaimKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    PersistentVolumeClaimKind.Group,
 				Resource: PersistentVolumeClaimResource.Name,
 			}, name)
 		}
+		return object.(*corev1.PersistentVolumeClaim).DeepCopy(), nil
 	}
-	return NewPersistentVolumeClaim(persistentVolumeClaim, c.Client), nil
-}
 
-func (c *persistentVolumeClaimsReader) List() ([]*PersistentVolumeClaim, error) {
-	list := &corev1.PersistentVolumeClaimList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &corev1.PersistentVolumeClaim{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.CoreV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), PersistentVolumeClaimKind.Scoped).
+		NamespaceIfScoped(namespace, PersistentVolumeClaimKind.Scoped).
 		Resource(PersistentVolumeClaimResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*PersistentVolumeClaim, 0, len(list.Items))
-	for _, persistentVolumeClaim := range list.Items {
+func (c *persistentVolumeClaimsReader) List(opts ...resource.ListOption) ([]*PersistentVolumeClaim, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, PersistentVolumeClaimKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*PersistentVolumeClaim, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *persistentVolumeClaimsReader) ListPages(handler func([]*PersistentVolumeClaim) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, PersistentVolumeClaimKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *persistentVolumeClaimsReader) list(namespace string, options metav1.ListOptions) ([]*PersistentVolumeClaim, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *persistentVolumeClaimsReader) filterItems(items []corev1.PersistentVolumeClaim) ([]*PersistentVolumeClaim, error) {
+	results := make([]*PersistentVolumeClaim, 0, len(items))
+	for _, persistentVolumeClaim := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   PersistentVolumeClaimKind.Group,
 			Version: PersistentVolumeClaimKind.Version,
@@ -100,3 +188,145 @@ func (c *persistentVolumeClaimsReader) List() ([]*PersistentVolumeClaim, error)
 	}
 	return results, nil
 }
+
+func (c *persistentVolumeClaimsReader) listItems(namespace string, options metav1.ListOptions) ([]corev1.PersistentVolumeClaim, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]corev1.PersistentVolumeClaim, 0, len(objects))
+			for _, object := range objects {
+				persistentVolumeClaim := object.(*corev1.PersistentVolumeClaim)
+				if selector.Matches(labels.Set(persistentVolumeClaim.Labels)) {
+					items = append(items, *persistentVolumeClaim.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &corev1.PersistentVolumeClaimList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, PersistentVolumeClaimKind.Scoped).
+		Resource(PersistentVolumeClaimResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *persistentVolumeClaimsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(PersistentVolumeClaimKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &corev1.PersistentVolumeClaimList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, PersistentVolumeClaimKind.Scoped).
+					Resource(PersistentVolumeClaimResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, PersistentVolumeClaimKind.Scoped).
+					Resource(PersistentVolumeClaimResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &corev1.PersistentVolumeClaim{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *persistentVolumeClaimsReader) Watch(ctx context.Context) (<-chan PersistentVolumeClaimEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, PersistentVolumeClaimKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, PersistentVolumeClaimKind.Scoped).
+			Resource(PersistentVolumeClaimResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan PersistentVolumeClaimEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			persistentVolumeClaim, ok := watchEvent.Object.(*corev1.PersistentVolumeClaim)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   PersistentVolumeClaimKind.Group,
+				Version: PersistentVolumeClaimKind.Version,
+				Kind:    PersistentVolumeClaimKind.Kind,
+			}, persistentVolumeClaim.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- PersistentVolumeClaimEvent{Type: watchEvent.Type, PersistentVolumeClaim: NewPersistentVolumeClaim(persistentVolumeClaim, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *persistentVolumeClaimsReader) WaitFor(ctx context.Context, predicate func(PersistentVolumeClaimEvent) (bool, error)) (PersistentVolumeClaimEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return PersistentVolumeClaimEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return PersistentVolumeClaimEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return PersistentVolumeClaimEvent{}, ctx.Err()
+}
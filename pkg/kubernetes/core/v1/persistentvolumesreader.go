@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type PersistentVolumesReader interface {
 	Get(name string) (*PersistentVolume, error)
-	List() ([]*PersistentVolume, error)
+	List(opts ...resource.ListOption) ([]*PersistentVolume, error)
+	ListPages(handler func([]*PersistentVolume) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan PersistentVolumeEvent, error)
+	WaitFor(ctx context.Context, predicate func(PersistentVolumeEvent) (bool, error)) (PersistentVolumeEvent, error)
+}
+
+type PersistentVolumeEvent struct {
+	Type             resource.EventType
+	PersistentVolume *PersistentVolume
 }
 
 func NewPersistentVolumesReader(client resource.Client, filter resource.Filter) PersistentVolumesReader {
@@ -30,24 +43,13 @@ type persistentVolumesReader struct {
 }
 
 func (c *persistentVolumesReader) Get(name string) (*PersistentVolume, error) {
-	persistentVolume := &corev1.PersistentVolume{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.CoreV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), PersistentVolumeKind.Scoped).
-		Resource(PersistentVolumeResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(persistentVolume)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, PersistentVolumeKind.Scoped) {
+		persistentVolume, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   PersistentVolumeKind.Group,
 			Version: PersistentVolumeKind.Version,
@@ -55,37 +57,123 @@ func (c *persistentVolumesReader) Get(name string) (*PersistentVolume, error) {
 		}, persistentVolume.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewPersistentVolume(persistentVolume, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    PersistentVolumeKind.Group,
+		Resource: PersistentVolumeResource.Name,
+	}, name)
+}
+
+func (c *persistentVolumesReader) get(namespace, name string) (*corev1.PersistentVolume, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, PersistentVolumeKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    PersistentVolumeKind.Group,
 				Resource: PersistentVolumeResource.Name,
 			}, name)
 		}
+		return object.(*corev1.PersistentVolume).DeepCopy(), nil
 	}
-	return NewPersistentVolume(persistentVolume, c.Client), nil
-}
 
-func (c *persistentVolumesReader) List() ([]*PersistentVolume, error) {
-	list := &corev1.PersistentVolumeList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &corev1.PersistentVolume{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.CoreV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), PersistentVolumeKind.Scoped).
+		NamespaceIfScoped(namespace, PersistentVolumeKind.Scoped).
 		Resource(PersistentVolumeResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*PersistentVolume, 0, len(list.Items))
-	for _, persistentVolume := range list.Items {
+func (c *persistentVolumesReader) List(opts ...resource.ListOption) ([]*PersistentVolume, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, PersistentVolumeKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*PersistentVolume, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *persistentVolumesReader) ListPages(handler func([]*PersistentVolume) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, PersistentVolumeKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *persistentVolumesReader) list(namespace string, options metav1.ListOptions) ([]*PersistentVolume, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *persistentVolumesReader) filterItems(items []corev1.PersistentVolume) ([]*PersistentVolume, error) {
+	results := make([]*PersistentVolume, 0, len(items))
+	for _, persistentVolume := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   PersistentVolumeKind.Group,
 			Version: PersistentVolumeKind.Version,
@@ -100,3 +188,145 @@ func (c *persistentVolumesReader) List() ([]*PersistentVolume, error) {
 	}
 	return results, nil
 }
+
+func (c *persistentVolumesReader) listItems(namespace string, options metav1.ListOptions) ([]corev1.PersistentVolume, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]corev1.PersistentVolume, 0, len(objects))
+			for _, object := range objects {
+				persistentVolume := object.(*corev1.PersistentVolume)
+				if selector.Matches(labels.Set(persistentVolume.Labels)) {
+					items = append(items, *persistentVolume.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &corev1.PersistentVolumeList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, PersistentVolumeKind.Scoped).
+		Resource(PersistentVolumeResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *persistentVolumesReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(PersistentVolumeKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &corev1.PersistentVolumeList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, PersistentVolumeKind.Scoped).
+					Resource(PersistentVolumeResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, PersistentVolumeKind.Scoped).
+					Resource(PersistentVolumeResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &corev1.PersistentVolume{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *persistentVolumesReader) Watch(ctx context.Context) (<-chan PersistentVolumeEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, PersistentVolumeKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, PersistentVolumeKind.Scoped).
+			Resource(PersistentVolumeResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan PersistentVolumeEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			persistentVolume, ok := watchEvent.Object.(*corev1.PersistentVolume)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   PersistentVolumeKind.Group,
+				Version: PersistentVolumeKind.Version,
+				Kind:    PersistentVolumeKind.Kind,
+			}, persistentVolume.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- PersistentVolumeEvent{Type: watchEvent.Type, PersistentVolume: NewPersistentVolume(persistentVolume, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *persistentVolumesReader) WaitFor(ctx context.Context, predicate func(PersistentVolumeEvent) (bool, error)) (PersistentVolumeEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return PersistentVolumeEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return PersistentVolumeEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return PersistentVolumeEvent{}, ctx.Err()
+}
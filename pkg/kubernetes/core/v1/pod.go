@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var PodResource = resource.Type{
 }
 
 func NewPod(pod *corev1.Pod, client resource.Client) *Pod {
+	client = resource.ForNamespace(client, pod.Namespace)
 	return &Pod{
 		Resource: resource.NewResource(pod.ObjectMeta, PodKind, client),
 		Object:   pod,
@@ -35,12 +37,11 @@ type Pod struct {
 }
 
 func (r *Pod) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.CoreV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, PodKind.Scoped).
 		Resource(PodResource.Name).
@@ -50,3 +51,94 @@ func (r *Pod) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *Pod) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.Pod{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, PodKind.Scoped).
+		Resource(PodResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Pod) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.Pod{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, PodKind.Scoped).
+		Resource(PodResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Pod) Update(mutate func(*corev1.Pod) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		pod := r.Object.DeepCopy()
+		if err := mutate(pod); err != nil {
+			return err
+		}
+		result := &corev1.Pod{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, PodKind.Scoped).
+			Resource(PodResource.Name).
+			Name(r.Name).
+			Body(pod).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *Pod) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *Pod) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *Pod) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *Pod) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type PodsReader interface {
 	Get(name string) (*Pod, error)
-	List() ([]*Pod, error)
+	List(opts ...resource.ListOption) ([]*Pod, error)
+	ListPages(handler func([]*Pod) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan PodEvent, error)
+	WaitFor(ctx context.Context, predicate func(PodEvent) (bool, error)) (PodEvent, error)
+}
+
+type PodEvent struct {
+	Type resource.EventType
+	Pod  *Pod
 }
 
 func NewPodsReader(client resource.Client, filter resource.Filter) PodsReader {
@@ -30,24 +43,13 @@ type podsReader struct {
 }
 
 func (c *podsReader) Get(name string) (*Pod, error) {
-	pod := &corev1.Pod{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.CoreV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), PodKind.Scoped).
-		Resource(PodResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(pod)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, PodKind.Scoped) {
+		pod, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   PodKind.Group,
 			Version: PodKind.Version,
@@ -55,37 +57,123 @@ func (c *podsReader) Get(name string) (*Pod, error) {
 		}, pod.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewPod(pod, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    PodKind.Group,
+		Resource: PodResource.Name,
+	}, name)
+}
+
+func (c *podsReader) get(namespace, name string) (*corev1.Pod, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, PodKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    PodKind.Group,
 				Resource: PodResource.Name,
 			}, name)
 		}
+		return object.(*corev1.Pod).DeepCopy(), nil
 	}
-	return NewPod(pod, c.Client), nil
-}
 
-func (c *podsReader) List() ([]*Pod, error) {
-	list := &corev1.PodList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &corev1.Pod{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.CoreV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), PodKind.Scoped).
+		NamespaceIfScoped(namespace, PodKind.Scoped).
 		Resource(PodResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*Pod, 0, len(list.Items))
-	for _, pod := range list.Items {
+func (c *podsReader) List(opts ...resource.ListOption) ([]*Pod, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, PodKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*Pod, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *podsReader) ListPages(handler func([]*Pod) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, PodKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *podsReader) list(namespace string, options metav1.ListOptions) ([]*Pod, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *podsReader) filterItems(items []corev1.Pod) ([]*Pod, error) {
+	results := make([]*Pod, 0, len(items))
+	for _, pod := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   PodKind.Group,
 			Version: PodKind.Version,
@@ -100,3 +188,145 @@ func (c *podsReader) List() ([]*Pod, error) {
 	}
 	return results, nil
 }
+
+func (c *podsReader) listItems(namespace string, options metav1.ListOptions) ([]corev1.Pod, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]corev1.Pod, 0, len(objects))
+			for _, object := range objects {
+				pod := object.(*corev1.Pod)
+				if selector.Matches(labels.Set(pod.Labels)) {
+					items = append(items, *pod.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &corev1.PodList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, PodKind.Scoped).
+		Resource(PodResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *podsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(PodKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &corev1.PodList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, PodKind.Scoped).
+					Resource(PodResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, PodKind.Scoped).
+					Resource(PodResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &corev1.Pod{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *podsReader) Watch(ctx context.Context) (<-chan PodEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, PodKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, PodKind.Scoped).
+			Resource(PodResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan PodEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			pod, ok := watchEvent.Object.(*corev1.Pod)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   PodKind.Group,
+				Version: PodKind.Version,
+				Kind:    PodKind.Kind,
+			}, pod.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- PodEvent{Type: watchEvent.Type, Pod: NewPod(pod, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *podsReader) WaitFor(ctx context.Context, predicate func(PodEvent) (bool, error)) (PodEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return PodEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return PodEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return PodEvent{}, ctx.Err()
+}
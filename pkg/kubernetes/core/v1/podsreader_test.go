@@ -0,0 +1,435 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newPodWatchServer returns a stand-in for the Kubernetes pod watch endpoint. The first watch returns the given
+// events and closes the stream, and later watches block until the client disconnects.
+func newPodWatchServer(t *testing.T, events ...watch.Event) *httptest.Server {
+	var requests int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/namespaces/test/pods" || req.URL.Query().Get("watch") != "true" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		if atomic.AddInt32(&requests, 1) > 1 {
+			<-req.Context().Done()
+			return
+		}
+		encoder := json.NewEncoder(w)
+		for _, event := range events {
+			assert.NoError(t, encoder.Encode(&metav1.WatchEvent{
+				Type:   string(event.Type),
+				Object: newRawPod(t, event.Object.(*corev1.Pod)),
+			}))
+		}
+	}))
+}
+
+func newRawPod(t *testing.T, pod *corev1.Pod) runtime.RawExtension {
+	pod.APIVersion = "v1"
+	pod.Kind = "Pod"
+	bytes, err := json.Marshal(pod)
+	assert.NoError(t, err)
+	return runtime.RawExtension{Raw: bytes}
+}
+
+func newWatchedPod(name string, resourceVersion string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "test",
+			Name:            name,
+			ResourceVersion: resourceVersion,
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+		},
+	}
+}
+
+func newPodEvents() []watch.Event {
+	return []watch.Event{
+		{Type: watch.Added, Object: newWatchedPod("foo", "1", corev1.PodPending)},
+		{Type: watch.Added, Object: newWatchedPod("bar", "2", corev1.PodPending)},
+		{Type: watch.Modified, Object: newWatchedPod("foo", "3", corev1.PodRunning)},
+		{Type: watch.Deleted, Object: newWatchedPod("foo", "4", corev1.PodRunning)},
+	}
+}
+
+func newTestPodsReader(t *testing.T, server *httptest.Server) PodsReader {
+	client := newTestClient(t, server)
+	filter := func(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
+		assert.Equal(t, PodKind.Kind, kind.Kind)
+		return meta.Name == "foo", nil
+	}
+	return NewPodsReader(client, filter)
+}
+
+func TestPodsWatch(t *testing.T) {
+	server := newPodWatchServer(t, newPodEvents()...)
+	defer server.Close()
+	reader := newTestPodsReader(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := reader.Watch(ctx)
+	assert.NoError(t, err)
+	event := <-events
+	assert.Equal(t, resource.EventAdded, event.Type)
+	assert.Equal(t, "foo", event.Pod.Name)
+	event = <-events
+	assert.Equal(t, resource.EventModified, event.Type)
+	assert.Equal(t, corev1.PodRunning, event.Pod.Object.Status.Phase)
+	event = <-events
+	assert.Equal(t, resource.EventDeleted, event.Type)
+	cancel()
+	_, ok := <-events
+	assert.False(t, ok)
+}
+
+func TestPodsWaitFor(t *testing.T) {
+	server := newPodWatchServer(t, newPodEvents()...)
+	defer server.Close()
+	reader := newTestPodsReader(t, server)
+
+	event, err := reader.WaitFor(context.Background(), func(event PodEvent) (bool, error) {
+		return event.Pod.Object.Status.Phase == corev1.PodRunning, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "3", event.Pod.Object.ResourceVersion)
+
+	server = newPodWatchServer(t)
+	defer server.Close()
+	reader = newTestPodsReader(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = reader.WaitFor(ctx, func(event PodEvent) (bool, error) {
+		return true, nil
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+// newPodListServer returns a stand-in for the Kubernetes pod list endpoint which serves the given pods
+// one per page and records the label selector of each request
+func newPodListServer(t *testing.T, selectors chan<- string, pods ...corev1.Pod) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/namespaces/test/pods" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query := req.URL.Query()
+		selectors <- query.Get("labelSelector")
+		index := 0
+		if query.Get("continue") != "" {
+			index, _ = strconv.Atoi(query.Get("continue"))
+		}
+		list := &corev1.PodList{
+			Items: pods[index : index+1],
+		}
+		if index+1 < len(pods) {
+			list.Continue = strconv.Itoa(index + 1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(list))
+	}))
+}
+
+func TestPodsListPages(t *testing.T) {
+	selectors := make(chan string, 3)
+	server := newPodListServer(t, selectors,
+		*newWatchedPod("foo", "1", corev1.PodRunning),
+		*newWatchedPod("bar", "2", corev1.PodRunning),
+		*newWatchedPod("foo", "3", corev1.PodRunning))
+	defer server.Close()
+	reader := newTestPodsReader(t, server)
+
+	pods, err := reader.List(resource.WithLabelSelector("app=onos"))
+	assert.NoError(t, err)
+	assert.Len(t, pods, 1)
+	assert.Equal(t, "app=onos", <-selectors)
+
+	versions := make([]string, 0)
+	err = reader.ListPages(func(pods []*Pod) (bool, error) {
+		for _, pod := range pods {
+			versions = append(versions, pod.Object.ResourceVersion)
+		}
+		return true, nil
+	}, resource.WithLabelSelector("app=onos"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "3"}, versions)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "app=onos", <-selectors)
+	}
+}
+
+type cachedTestClient struct {
+	*testClient
+	cache *resource.Cache
+}
+
+func (c *cachedTestClient) Cache() *resource.Cache {
+	return c.cache
+}
+
+var _ resource.Cached = &cachedTestClient{}
+
+func TestCachedPodsReader(t *testing.T) {
+	var lists int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/namespaces/test/pods" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Query().Get("watch") == "true" {
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-req.Context().Done()
+			return
+		}
+		atomic.AddInt32(&lists, 1)
+		foo := newWatchedPod("foo", "1", corev1.PodRunning)
+		foo.Labels = map[string]string{"app": "onos"}
+		bar := newWatchedPod("bar", "1", corev1.PodRunning)
+		list := &corev1.PodList{
+			ListMeta: metav1.ListMeta{ResourceVersion: "1"},
+			Items:    []corev1.Pod{*foo, *bar},
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(list))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := &cachedTestClient{
+		testClient: newTestClient(t, server),
+		cache:      resource.NewCache(ctx),
+	}
+	reader := NewPodsReader(client, resource.NoFilter)
+
+	pods, err := reader.List()
+	assert.NoError(t, err)
+	assert.Len(t, pods, 2)
+	assert.Equal(t, "bar", pods[0].Name)
+	assert.Equal(t, "foo", pods[1].Name)
+
+	pods, err = reader.List(resource.WithLabelSelector("app=onos"))
+	assert.NoError(t, err)
+	assert.Len(t, pods, 1)
+	assert.Equal(t, "foo", pods[0].Name)
+
+	pod, err := reader.Get("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", pod.Name)
+	pod.Object.Labels["app"] = "changed"
+
+	pod, err = reader.Get("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "onos", pod.Object.Labels["app"])
+
+	_, err = reader.Get("baz")
+	assert.True(t, errors.IsNotFound(err))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&lists))
+}
+
+type multiNamespacedTestClient struct {
+	*testClient
+}
+
+func (c *multiNamespacedTestClient) Namespaces() []string {
+	return []string{"test", "other"}
+}
+
+var _ resource.MultiNamespaced = &multiNamespacedTestClient{}
+
+// newNamespacesServer returns a stand-in for the Kubernetes API server which serves a pod named foo in each of the
+// test and other namespaces, a pod named bar in the other namespace, and a cluster scoped node
+func newNamespacesServer(t *testing.T) *httptest.Server {
+	newPod := func(namespace, name string) corev1.Pod {
+		pod := newWatchedPod(name, "1", corev1.PodRunning)
+		pod.Namespace = namespace
+		return *pod
+	}
+	pods := map[string][]corev1.Pod{
+		"test":  {newPod("test", "foo")},
+		"other": {newPod("other", "foo"), newPod("other", "bar")},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path == "/api/v1/nodes" {
+			assert.NoError(t, json.NewEncoder(w).Encode(&corev1.NodeList{
+				Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}},
+			}))
+			return
+		}
+		var namespace, name string
+		parts := strings.Split(strings.TrimPrefix(req.URL.Path, "/api/v1/namespaces/"), "/")
+		if len(parts) < 2 || parts[1] != "pods" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		namespace = parts[0]
+		if len(parts) > 2 {
+			name = parts[2]
+		}
+		if req.URL.Query().Get("watch") == "true" {
+			w.WriteHeader(http.StatusOK)
+			encoder := json.NewEncoder(w)
+			for _, pod := range pods[namespace] {
+				pod := pod
+				assert.NoError(t, encoder.Encode(&metav1.WatchEvent{
+					Type:   string(watch.Added),
+					Object: newRawPod(t, &pod),
+				}))
+			}
+			w.(http.Flusher).Flush()
+			<-req.Context().Done()
+			return
+		}
+		if name == "" {
+			items := pods[namespace]
+			if limit, err := strconv.Atoi(req.URL.Query().Get("limit")); err == nil && limit < len(items) {
+				items = items[:limit]
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(&corev1.PodList{Items: items}))
+			return
+		}
+		for _, pod := range pods[namespace] {
+			if pod.Name == name {
+				assert.NoError(t, json.NewEncoder(w).Encode(&pod))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+}
+
+func TestPodsReaderNamespaces(t *testing.T) {
+	server := newNamespacesServer(t)
+	defer server.Close()
+	client := &multiNamespacedTestClient{newTestClient(t, server)}
+
+	pods, err := NewPodsReader(client, resource.NoFilter).List()
+	assert.NoError(t, err)
+	assert.Len(t, pods, 3)
+
+	// The limit applies across all the namespaces
+	pods, err = NewPodsReader(client, resource.NoFilter).List(resource.WithLimit(2))
+	assert.NoError(t, err)
+	assert.Len(t, pods, 2)
+	assert.Equal(t, "test", pods[0].Namespace)
+	assert.Equal(t, "other", pods[1].Namespace)
+
+	pods, err = NewPodsReader(client, resource.NoFilter).List(resource.WithLimit(1))
+	assert.NoError(t, err)
+	assert.Len(t, pods, 1)
+
+	// Objects are read from the first namespace that contains them and passes the filter
+	pod, err := NewPodsReader(client, resource.NoFilter).Get("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "test", pod.Namespace)
+
+	pod, err = NewPodsReader(client, resource.NoFilter).Get("bar")
+	assert.NoError(t, err)
+	assert.Equal(t, "other", pod.Namespace)
+
+	otherFilter := func(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
+		return meta.Namespace == "other", nil
+	}
+	pod, err = NewPodsReader(client, otherFilter).Get("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "other", pod.Namespace)
+
+	_, err = NewPodsReader(client, resource.NoFilter).Get("baz")
+	assert.True(t, errors.IsNotFound(err))
+
+	namespaces := make([]string, 0)
+	err = NewPodsReader(client, resource.NoFilter).ListPages(func(pods []*Pod) (bool, error) {
+		for _, pod := range pods {
+			namespaces = append(namespaces, pod.Namespace)
+		}
+		return true, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"test", "other", "other"}, namespaces)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := NewPodsReader(client, resource.NoFilter).Watch(ctx)
+	assert.NoError(t, err)
+	names := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		event := <-events
+		names[event.Pod.Namespace+"/"+event.Pod.Name] = true
+	}
+	assert.Len(t, names, 3)
+	cancel()
+	for range events {
+	}
+
+	// Cluster scoped resources are read cluster-wide
+	nodes, err := NewNodesReader(client, resource.NoFilter).List()
+	assert.NoError(t, err)
+	assert.Len(t, nodes, 1)
+	assert.Equal(t, "node-1", nodes[0].Name)
+}
+
+func TestClusterScopedReader(t *testing.T) {
+	paths := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		volume := corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv-1"}}
+		if req.URL.Path == "/api/v1/persistentvolumes" {
+			assert.NoError(t, json.NewEncoder(w).Encode(&corev1.PersistentVolumeList{Items: []corev1.PersistentVolume{volume}}))
+		} else if req.URL.Path == "/api/v1/persistentvolumes/pv-1" {
+			assert.NoError(t, json.NewEncoder(w).Encode(&volume))
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := &multiNamespacedTestClient{newTestClient(t, server)}
+	assert.False(t, PersistentVolumeKind.Scoped)
+
+	// Cluster scoped kinds are read once, cluster-wide, rather than in each of the client's namespaces
+	volumes, err := NewPersistentVolumesReader(client, resource.NoFilter).List()
+	assert.NoError(t, err)
+	assert.Len(t, volumes, 1)
+	volume, err := NewPersistentVolumesReader(client, resource.NoFilter).Get("pv-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "pv-1", volume.Name)
+	assert.Equal(t, []string{"/api/v1/persistentvolumes", "/api/v1/persistentvolumes/pv-1"}, paths)
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var PodTemplateResource = resource.Type{
 }
 
 func NewPodTemplate(podTemplate *corev1.PodTemplate, client resource.Client) *PodTemplate {
+	client = resource.ForNamespace(client, podTemplate.Namespace)
 	return &PodTemplate{
 		Resource: resource.NewResource(podTemplate.ObjectMeta, PodTemplateKind, client),
 		Object:   podTemplate,
@@ -35,12 +37,11 @@ type PodTemplate struct {
 }
 
 func (r *PodTemplate) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.CoreV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, PodTemplateKind.Scoped).
 		Resource(PodTemplateResource.Name).
@@ -50,3 +51,94 @@ func (r *PodTemplate) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *PodTemplate) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.PodTemplate{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, PodTemplateKind.Scoped).
+		Resource(PodTemplateResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *PodTemplate) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.PodTemplate{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, PodTemplateKind.Scoped).
+		Resource(PodTemplateResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *PodTemplate) Update(mutate func(*corev1.PodTemplate) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		podTemplate := r.Object.DeepCopy()
+		if err := mutate(podTemplate); err != nil {
+			return err
+		}
+		result := &corev1.PodTemplate{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, PodTemplateKind.Scoped).
+			Resource(PodTemplateResource.Name).
+			Name(r.Name).
+			Body(podTemplate).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *PodTemplate) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *PodTemplate) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *PodTemplate) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *PodTemplate) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type PodTemplatesReader interface {
 	Get(name string) (*PodTemplate, error)
-	List() ([]*PodTemplate, error)
+	List(opts ...resource.ListOption) ([]*PodTemplate, error)
+	ListPages(handler func([]*PodTemplate) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan PodTemplateEvent, error)
+	WaitFor(ctx context.Context, predicate func(PodTemplateEvent) (bool, error)) (PodTemplateEvent, error)
+}
+
+type PodTemplateEvent struct {
+	Type        resource.EventType
+	PodTemplate *PodTemplate
 }
 
 func NewPodTemplatesReader(client resource.Client, filter resource.Filter) PodTemplatesReader {
@@ -30,24 +43,13 @@ type podTemplatesReader struct {
 }
 
 func (c *podTemplatesReader) Get(name string) (*PodTemplate, error) {
-	podTemplate := &corev1.PodTemplate{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.CoreV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), PodTemplateKind.Scoped).
-		Resource(PodTemplateResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(podTemplate)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, PodTemplateKind.Scoped) {
+		podTemplate, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   PodTemplateKind.Group,
 			Version: PodTemplateKind.Version,
@@ -55,37 +57,123 @@ func (c *podTemplatesReader) Get(name string) (*PodTemplate, error) {
 		}, podTemplate.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewPodTemplate(podTemplate, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    PodTemplateKind.Group,
+		Resource: PodTemplateResource.Name,
+	}, name)
+}
+
+func (c *podTemplatesReader) get(namespace, name string) (*corev1.PodTemplate, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, PodTemplateKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    PodTemplateKind.Group,
 				Resource: PodTemplateResource.Name,
 			}, name)
 		}
+		return object.(*corev1.PodTemplate).DeepCopy(), nil
 	}
-	return NewPodTemplate(podTemplate, c.Client), nil
-}
 
-func (c *podTemplatesReader) List() ([]*PodTemplate, error) {
-	list := &corev1.PodTemplateList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &corev1.PodTemplate{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.CoreV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), PodTemplateKind.Scoped).
+		NamespaceIfScoped(namespace, PodTemplateKind.Scoped).
 		Resource(PodTemplateResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*PodTemplate, 0, len(list.Items))
-	for _, podTemplate := range list.Items {
+func (c *podTemplatesReader) List(opts ...resource.ListOption) ([]*PodTemplate, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, PodTemplateKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*PodTemplate, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *podTemplatesReader) ListPages(handler func([]*PodTemplate) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, PodTemplateKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *podTemplatesReader) list(namespace string, options metav1.ListOptions) ([]*PodTemplate, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *podTemplatesReader) filterItems(items []corev1.PodTemplate) ([]*PodTemplate, error) {
+	results := make([]*PodTemplate, 0, len(items))
+	for _, podTemplate := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   PodTemplateKind.Group,
 			Version: PodTemplateKind.Version,
@@ -100,3 +188,145 @@ func (c *podTemplatesReader) List() ([]*PodTemplate, error) {
 	}
 	return results, nil
 }
+
+func (c *podTemplatesReader) listItems(namespace string, options metav1.ListOptions) ([]corev1.PodTemplate, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]corev1.PodTemplate, 0, len(objects))
+			for _, object := range objects {
+				podTemplate := object.(*corev1.PodTemplate)
+				if selector.Matches(labels.Set(podTemplate.Labels)) {
+					items = append(items, *podTemplate.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &corev1.PodTemplateList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, PodTemplateKind.Scoped).
+		Resource(PodTemplateResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *podTemplatesReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(PodTemplateKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &corev1.PodTemplateList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, PodTemplateKind.Scoped).
+					Resource(PodTemplateResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, PodTemplateKind.Scoped).
+					Resource(PodTemplateResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &corev1.PodTemplate{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *podTemplatesReader) Watch(ctx context.Context) (<-chan PodTemplateEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, PodTemplateKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, PodTemplateKind.Scoped).
+			Resource(PodTemplateResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan PodTemplateEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			podTemplate, ok := watchEvent.Object.(*corev1.PodTemplate)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   PodTemplateKind.Group,
+				Version: PodTemplateKind.Version,
+				Kind:    PodTemplateKind.Kind,
+			}, podTemplate.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- PodTemplateEvent{Type: watchEvent.Type, PodTemplate: NewPodTemplate(podTemplate, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *podTemplatesReader) WaitFor(ctx context.Context, predicate func(PodTemplateEvent) (bool, error)) (PodTemplateEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return PodTemplateEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return PodTemplateEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return PodTemplateEvent{}, ctx.Err()
+}
@@ -0,0 +1,161 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+const portForwardAddress = "127.0.0.1"
+
+// PortForward forwards a random local port to the given port of the pod. It returns the local address of the
+// forwarded port and a function that stops forwarding. Forwarding is also stopped when the context is done.
+func (r *Pod) PortForward(ctx context.Context, remotePort int) (string, func(), error) {
+	transport, upgrader, err := spdy.RoundTripperFor(r.Config())
+	if err != nil {
+		return "", nil, err
+	}
+
+	url := r.Clientset().CoreV1().
+		RESTClient().
+		Post().
+		Namespace(r.Namespace).
+		Resource(PodResource.Name).
+		Name(r.Name).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{portForwardAddress}, []string{fmt.Sprintf("0:%d", remotePort)}, stopCh, readyCh, ioutil.Discard, ioutil.Discard)
+	if err != nil {
+		return "", nil, err
+	}
+
+	once := &sync.Once{}
+	stop := func() {
+		once.Do(func() {
+			close(stopCh)
+		})
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- forwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-readyCh:
+	case err := <-errCh:
+		stop()
+		if err == nil {
+			err = errors.New("port forwarding stopped")
+		}
+		return "", nil, err
+	case <-ctx.Done():
+		stop()
+		return "", nil, ctx.Err()
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			stop()
+		case <-stopCh:
+		}
+	}()
+
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		stop()
+		return "", nil, err
+	}
+	return net.JoinHostPort(portForwardAddress, strconv.Itoa(int(ports[0].Local))), stop, nil
+}
+
+// PortForward forwards a random local port to the given port of the service through a ready pod backing the
+// service. It returns the local address of the forwarded port and a function that stops forwarding.
+func (r *Service) PortForward(ctx context.Context, port int) (string, func(), error) {
+	service, err := r.Clientset().CoreV1().Services(r.Namespace).Get(r.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", nil, err
+	}
+
+	var servicePort *corev1.ServicePort
+	for _, p := range service.Spec.Ports {
+		if int(p.Port) == port {
+			servicePort = &p
+			break
+		}
+	}
+	if servicePort == nil {
+		return "", nil, fmt.Errorf("service %s has no port %d", r.Name, port)
+	}
+	if len(service.Spec.Selector) == 0 {
+		return "", nil, fmt.Errorf("service %s has no selector", r.Name)
+	}
+
+	pods, err := r.Clientset().CoreV1().Pods(r.Namespace).List(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, pod := range pods.Items {
+		if ready, err := isPodReady(&pod); err != nil || !ready || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		targetPort, err := getTargetPort(&pod, servicePort)
+		if err != nil {
+			return "", nil, err
+		}
+		copy := pod
+		return NewPod(&copy, r.Client).PortForward(ctx, targetPort)
+	}
+	return "", nil, fmt.Errorf("service %s has no ready pods", r.Name)
+}
+
+// getTargetPort resolves the container port of the given pod targeted by the service port
+func getTargetPort(pod *corev1.Pod, servicePort *corev1.ServicePort) (int, error) {
+	if servicePort.TargetPort.Type == intstr.Int {
+		if servicePort.TargetPort.IntVal == 0 {
+			return int(servicePort.Port), nil
+		}
+		return int(servicePort.TargetPort.IntVal), nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == servicePort.TargetPort.StrVal {
+				return int(containerPort.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("pod %s has no port named %s", pod.Name, servicePort.TargetPort.StrVal)
+}
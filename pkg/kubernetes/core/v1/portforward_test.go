@@ -0,0 +1,55 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"testing"
+)
+
+func TestGetTargetPort(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "onos",
+					Ports: []corev1.ContainerPort{
+						{
+							Name:          "grpc",
+							ContainerPort: 5150,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	port, err := getTargetPort(pod, &corev1.ServicePort{Port: 8181})
+	assert.NoError(t, err)
+	assert.Equal(t, 8181, port)
+
+	port, err = getTargetPort(pod, &corev1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8080)})
+	assert.NoError(t, err)
+	assert.Equal(t, 8080, port)
+
+	port, err = getTargetPort(pod, &corev1.ServicePort{Port: 5150, TargetPort: intstr.FromString("grpc")})
+	assert.NoError(t, err)
+	assert.Equal(t, 5150, port)
+
+	_, err = getTargetPort(pod, &corev1.ServicePort{Port: 5150, TargetPort: intstr.FromString("http")})
+	assert.Error(t, err)
+}
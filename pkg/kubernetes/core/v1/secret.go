@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var SecretResource = resource.Type{
 }
 
 func NewSecret(secret *corev1.Secret, client resource.Client) *Secret {
+	client = resource.ForNamespace(client, secret.Namespace)
 	return &Secret{
 		Resource: resource.NewResource(secret.ObjectMeta, SecretKind, client),
 		Object:   secret,
@@ -35,12 +37,11 @@ type Secret struct {
 }
 
 func (r *Secret) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.CoreV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, SecretKind.Scoped).
 		Resource(SecretResource.Name).
@@ -50,3 +51,94 @@ func (r *Secret) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *Secret) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.Secret{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, SecretKind.Scoped).
+		Resource(SecretResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Secret) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.Secret{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, SecretKind.Scoped).
+		Resource(SecretResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Secret) Update(mutate func(*corev1.Secret) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		secret := r.Object.DeepCopy()
+		if err := mutate(secret); err != nil {
+			return err
+		}
+		result := &corev1.Secret{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, SecretKind.Scoped).
+			Resource(SecretResource.Name).
+			Name(r.Name).
+			Body(secret).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *Secret) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *Secret) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *Secret) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *Secret) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
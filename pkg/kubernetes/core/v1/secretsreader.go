@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type SecretsReader interface {
 	Get(name string) (*Secret, error)
-	List() ([]*Secret, error)
+	List(opts ...resource.ListOption) ([]*Secret, error)
+	ListPages(handler func([]*Secret) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan SecretEvent, error)
+	WaitFor(ctx context.Context, predicate func(SecretEvent) (bool, error)) (SecretEvent, error)
+}
+
+type SecretEvent struct {
+	Type   resource.EventType
+	Secret *Secret
 }
 
 func NewSecretsReader(client resource.Client, filter resource.Filter) SecretsReader {
@@ -30,24 +43,13 @@ type secretsReader struct {
 }
 
 func (c *secretsReader) Get(name string) (*Secret, error) {
-	secret := &corev1.Secret{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.CoreV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), SecretKind.Scoped).
-		Resource(SecretResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(secret)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, SecretKind.Scoped) {
+		secret, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   SecretKind.Group,
 			Version: SecretKind.Version,
@@ -55,37 +57,123 @@ func (c *secretsReader) Get(name string) (*Secret, error) {
 		}, secret.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewSecret(secret, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    SecretKind.Group,
+		Resource: SecretResource.Name,
+	}, name)
+}
+
+func (c *secretsReader) get(namespace, name string) (*corev1.Secret, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, SecretKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    SecretKind.Group,
 				Resource: SecretResource.Name,
 			}, name)
 		}
+		return object.(*corev1.Secret).DeepCopy(), nil
 	}
-	return NewSecret(secret, c.Client), nil
-}
 
-func (c *secretsReader) List() ([]*Secret, error) {
-	list := &corev1.SecretList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &corev1.Secret{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.CoreV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), SecretKind.Scoped).
+		NamespaceIfScoped(namespace, SecretKind.Scoped).
 		Resource(SecretResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*Secret, 0, len(list.Items))
-	for _, secret := range list.Items {
+func (c *secretsReader) List(opts ...resource.ListOption) ([]*Secret, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, SecretKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*Secret, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *secretsReader) ListPages(handler func([]*Secret) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, SecretKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *secretsReader) list(namespace string, options metav1.ListOptions) ([]*Secret, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *secretsReader) filterItems(items []corev1.Secret) ([]*Secret, error) {
+	results := make([]*Secret, 0, len(items))
+	for _, secret := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   SecretKind.Group,
 			Version: SecretKind.Version,
@@ -100,3 +188,145 @@ func (c *secretsReader) List() ([]*Secret, error) {
 	}
 	return results, nil
 }
+
+func (c *secretsReader) listItems(namespace string, options metav1.ListOptions) ([]corev1.Secret, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]corev1.Secret, 0, len(objects))
+			for _, object := range objects {
+				secret := object.(*corev1.Secret)
+				if selector.Matches(labels.Set(secret.Labels)) {
+					items = append(items, *secret.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &corev1.SecretList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, SecretKind.Scoped).
+		Resource(SecretResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *secretsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(SecretKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &corev1.SecretList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, SecretKind.Scoped).
+					Resource(SecretResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, SecretKind.Scoped).
+					Resource(SecretResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &corev1.Secret{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *secretsReader) Watch(ctx context.Context) (<-chan SecretEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, SecretKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, SecretKind.Scoped).
+			Resource(SecretResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan SecretEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			secret, ok := watchEvent.Object.(*corev1.Secret)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   SecretKind.Group,
+				Version: SecretKind.Version,
+				Kind:    SecretKind.Kind,
+			}, secret.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- SecretEvent{Type: watchEvent.Type, Secret: NewSecret(secret, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *secretsReader) WaitFor(ctx context.Context, predicate func(SecretEvent) (bool, error)) (SecretEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return SecretEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return SecretEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return SecretEvent{}, ctx.Err()
+}
@@ -3,10 +3,12 @@
 package v1
 
 import (
+	discoveryv1beta1 "github.com/onosproject/helm-go/pkg/kubernetes/discovery/v1beta1"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,10 +25,12 @@ var ServiceResource = resource.Type{
 }
 
 func NewService(service *corev1.Service, client resource.Client) *Service {
+	client = resource.ForNamespace(client, service.Namespace)
 	return &Service{
-		Resource:           resource.NewResource(service.ObjectMeta, ServiceKind, client),
-		Object:             service,
-		EndpointsReference: NewEndpointsReference(client, resource.NewUIDFilter(service.UID)),
+		Resource:                resource.NewResource(service.ObjectMeta, ServiceKind, client),
+		Object:                  service,
+		EndpointsReference:      NewEndpointsReference(client, resource.NewUIDFilter(service.UID)),
+		EndpointSlicesReference: discoveryv1beta1.NewEndpointSlicesReference(client, resource.NewUIDFilter(service.UID)),
 	}
 }
 
@@ -34,15 +38,15 @@ type Service struct {
 	*resource.Resource
 	Object *corev1.Service
 	EndpointsReference
+	discoveryv1beta1.EndpointSlicesReference
 }
 
 func (r *Service) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.CoreV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, ServiceKind.Scoped).
 		Resource(ServiceResource.Name).
@@ -52,3 +56,94 @@ func (r *Service) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *Service) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.Service{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, ServiceKind.Scoped).
+		Resource(ServiceResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Service) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &corev1.Service{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, ServiceKind.Scoped).
+		Resource(ServiceResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Service) Update(mutate func(*corev1.Service) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		service := r.Object.DeepCopy()
+		if err := mutate(service); err != nil {
+			return err
+		}
+		result := &corev1.Service{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, ServiceKind.Scoped).
+			Resource(ServiceResource.Name).
+			Name(r.Name).
+			Body(service).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *Service) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *Service) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *Service) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *Service) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
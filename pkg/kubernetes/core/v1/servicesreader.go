@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type ServicesReader interface {
 	Get(name string) (*Service, error)
-	List() ([]*Service, error)
+	List(opts ...resource.ListOption) ([]*Service, error)
+	ListPages(handler func([]*Service) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan ServiceEvent, error)
+	WaitFor(ctx context.Context, predicate func(ServiceEvent) (bool, error)) (ServiceEvent, error)
+}
+
+type ServiceEvent struct {
+	Type    resource.EventType
+	Service *Service
 }
 
 func NewServicesReader(client resource.Client, filter resource.Filter) ServicesReader {
@@ -30,24 +43,13 @@ type servicesReader struct {
 }
 
 func (c *servicesReader) Get(name string) (*Service, error) {
-	service := &corev1.Service{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.CoreV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), ServiceKind.Scoped).
-		Resource(ServiceResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(service)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, ServiceKind.Scoped) {
+		service, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   ServiceKind.Group,
 			Version: ServiceKind.Version,
@@ -55,37 +57,123 @@ func (c *servicesReader) Get(name string) (*Service, error) {
 		}, service.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewService(service, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    ServiceKind.Group,
+		Resource: ServiceResource.Name,
+	}, name)
+}
+
+func (c *servicesReader) get(namespace, name string) (*corev1.Service, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, ServiceKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    ServiceKind.Group,
 				Resource: ServiceResource.Name,
 			}, name)
 		}
+		return object.(*corev1.Service).DeepCopy(), nil
 	}
-	return NewService(service, c.Client), nil
-}
 
-func (c *servicesReader) List() ([]*Service, error) {
-	list := &corev1.ServiceList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &corev1.Service{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.CoreV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), ServiceKind.Scoped).
+		NamespaceIfScoped(namespace, ServiceKind.Scoped).
 		Resource(ServiceResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*Service, 0, len(list.Items))
-	for _, service := range list.Items {
+func (c *servicesReader) List(opts ...resource.ListOption) ([]*Service, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, ServiceKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*Service, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *servicesReader) ListPages(handler func([]*Service) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, ServiceKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *servicesReader) list(namespace string, options metav1.ListOptions) ([]*Service, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *servicesReader) filterItems(items []corev1.Service) ([]*Service, error) {
+	results := make([]*Service, 0, len(items))
+	for _, service := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   ServiceKind.Group,
 			Version: ServiceKind.Version,
@@ -100,3 +188,145 @@ func (c *servicesReader) List() ([]*Service, error) {
 	}
 	return results, nil
 }
+
+func (c *servicesReader) listItems(namespace string, options metav1.ListOptions) ([]corev1.Service, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]corev1.Service, 0, len(objects))
+			for _, object := range objects {
+				service := object.(*corev1.Service)
+				if selector.Matches(labels.Set(service.Labels)) {
+					items = append(items, *service.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &corev1.ServiceList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, ServiceKind.Scoped).
+		Resource(ServiceResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *servicesReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(ServiceKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &corev1.ServiceList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, ServiceKind.Scoped).
+					Resource(ServiceResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, ServiceKind.Scoped).
+					Resource(ServiceResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &corev1.Service{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *servicesReader) Watch(ctx context.Context) (<-chan ServiceEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, ServiceKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, ServiceKind.Scoped).
+			Resource(ServiceResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ServiceEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			service, ok := watchEvent.Object.(*corev1.Service)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   ServiceKind.Group,
+				Version: ServiceKind.Version,
+				Kind:    ServiceKind.Kind,
+			}, service.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- ServiceEvent{Type: watchEvent.Type, Service: NewService(service, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *servicesReader) WaitFor(ctx context.Context, predicate func(ServiceEvent) (bool, error)) (ServiceEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return ServiceEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return ServiceEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return ServiceEvent{}, ctx.Err()
+}
@@ -0,0 +1,108 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+// Ready returns whether the pod is ready or has completed, or an error if the pod failed
+func (r *Pod) Ready() (bool, error) {
+	pod, err := r.Clientset().CoreV1().Pods(r.Namespace).Get(r.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return isPodReady(pod)
+}
+
+// Wait waits for the pod to become ready
+func (r *Pod) Wait(timeout time.Duration) error {
+	return resource.Wait(r.Ready, timeout)
+}
+
+// Ready returns whether the service has ready endpoints
+func (r *Service) Ready() (bool, error) {
+	service, err := r.Clientset().CoreV1().Services(r.Namespace).Get(r.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if !hasServiceEndpoints(service) {
+		return true, nil
+	}
+	endpoints, err := r.Clientset().CoreV1().Endpoints(r.Namespace).Get(r.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return isEndpointsReady(endpoints), nil
+}
+
+// Wait waits for the service to become ready
+func (r *Service) Wait(timeout time.Duration) error {
+	return resource.Wait(r.Ready, timeout)
+}
+
+// Ready returns whether the persistent volume claim is bound
+func (r *PersistentVolumeClaim) Ready() (bool, error) {
+	claim, err := r.Clientset().CoreV1().PersistentVolumeClaims(r.Namespace).Get(r.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return claim.Status.Phase == corev1.ClaimBound, nil
+}
+
+// Wait waits for the persistent volume claim to be bound
+func (r *PersistentVolumeClaim) Wait(timeout time.Duration) error {
+	return resource.Wait(r.Ready, timeout)
+}
+
+func isPodReady(pod *corev1.Pod) (bool, error) {
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return true, nil
+	case corev1.PodFailed:
+		return false, fmt.Errorf("pod %s failed: %s", pod.Name, pod.Status.Message)
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue, nil
+		}
+	}
+	return false, nil
+}
+
+// hasServiceEndpoints returns whether the service is expected to have endpoints managed by a selector
+func hasServiceEndpoints(service *corev1.Service) bool {
+	return service.Spec.Type != corev1.ServiceTypeExternalName && len(service.Spec.Selector) > 0
+}
+
+func isEndpointsReady(endpoints *corev1.Endpoints) bool {
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+var _ resource.Waiter = &Pod{}
+var _ resource.Waiter = &Service{}
+var _ resource.Waiter = &PersistentVolumeClaim{}
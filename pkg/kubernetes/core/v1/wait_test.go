@@ -0,0 +1,74 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestPodReady(t *testing.T) {
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.PodReady,
+					Status: corev1.ConditionFalse,
+				},
+			},
+		},
+	}
+	ready, err := isPodReady(pod)
+	assert.NoError(t, err)
+	assert.False(t, ready)
+
+	pod.Status.Conditions[0].Status = corev1.ConditionTrue
+	ready, err = isPodReady(pod)
+	assert.NoError(t, err)
+	assert.True(t, ready)
+
+	pod.Status.Phase = corev1.PodFailed
+	_, err = isPodReady(pod)
+	assert.Error(t, err)
+}
+
+func TestServiceReady(t *testing.T) {
+	service := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeExternalName,
+		},
+	}
+	assert.False(t, hasServiceEndpoints(service))
+
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	assert.False(t, hasServiceEndpoints(service))
+
+	service.Spec.Selector = map[string]string{"app": "onos"}
+	assert.True(t, hasServiceEndpoints(service))
+
+	endpoints := &corev1.Endpoints{
+		Subsets: []corev1.EndpointSubset{
+			{
+				NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+			},
+		},
+	}
+	assert.False(t, isEndpointsReady(endpoints))
+
+	endpoints.Subsets[0].Addresses = []corev1.EndpointAddress{{IP: "10.0.0.2"}}
+	assert.True(t, isEndpointsReady(endpoints))
+}
@@ -0,0 +1,82 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	"strings"
+)
+
+// All lists the objects of every listable kind served by the cluster that pass the given filter. Namespaced kinds
+// are listed in each of the client's namespaces, and cluster scoped kinds are listed cluster-wide. Kinds the client
+// is not permitted to list are skipped.
+func All(client resource.Client, filter resource.Filter) ([]*Object, error) {
+	resourceLists, err := client.Clientset().Discovery().ServerPreferredResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, err
+	}
+
+	objects := make([]*Object, 0)
+	uids := make(map[types.UID]bool)
+	for _, resourceList := range resourceLists {
+		groupVersion, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, apiResource := range resourceList.APIResources {
+			if !isListable(apiResource) {
+				continue
+			}
+			results, err := newMappedClient(client, filter, getMapping(groupVersion, apiResource)).List()
+			if errors.IsForbidden(err) || errors.IsNotFound(err) || errors.IsMethodNotSupported(err) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			// The same object may be served by more than one group, e.g. extensions and apps
+			for _, object := range results {
+				if !uids[object.UID] {
+					uids[object.UID] = true
+					objects = append(objects, object)
+				}
+			}
+		}
+	}
+	return objects, nil
+}
+
+// isListable returns whether the given API resource is a top-level resource that supports listing
+func isListable(apiResource metav1.APIResource) bool {
+	return !strings.Contains(apiResource.Name, "/") && sets.NewString(apiResource.Verbs...).Has("list")
+}
+
+func getMapping(groupVersion schema.GroupVersion, apiResource metav1.APIResource) *meta.RESTMapping {
+	scope := meta.RESTScopeRoot
+	if apiResource.Namespaced {
+		scope = meta.RESTScopeNamespace
+	}
+	return &meta.RESTMapping{
+		Resource:         groupVersion.WithResource(apiResource.Name),
+		GroupVersionKind: groupVersion.WithKind(apiResource.Kind),
+		Scope:            scope,
+	}
+}
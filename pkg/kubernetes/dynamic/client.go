@@ -0,0 +1,337 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	kubedynamic "k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	"sort"
+	"sync"
+)
+
+// Client is a reader and writer for unstructured objects of a single kind
+type Client interface {
+	// Get gets the object with the given name from the first of the client's namespaces that contains it
+	Get(name string) (*Object, error)
+	// List lists the objects of the client's kind in each of the client's namespaces. A limit applies to the objects
+	// returned across all the namespaces.
+	List(opts ...resource.ListOption) ([]*Object, error)
+	// Create creates the given object in its namespace or, if it has none, the client namespace
+	Create(object *unstructured.Unstructured) (*Object, error)
+	// Update replaces the given object in its namespace or, if it has none, the client namespace
+	Update(object *unstructured.Unstructured) (*Object, error)
+	// Patch patches the object with the given name
+	Patch(name string, patchType types.PatchType, data []byte) (*Object, error)
+	// Delete deletes the object with the given name
+	Delete(name string) error
+}
+
+// NewClient returns a new client for unstructured objects of the given kind. The kind is resolved to a resource
+// through discovery the first time the client is used.
+func NewClient(client resource.Client, filter resource.Filter, kind schema.GroupVersionKind) Client {
+	return &dynamicClient{
+		Client: client,
+		filter: filter,
+		kind:   kind,
+	}
+}
+
+func newMappedClient(client resource.Client, filter resource.Filter, mapping *meta.RESTMapping) Client {
+	return &dynamicClient{
+		Client:  client,
+		filter:  filter,
+		kind:    mapping.GroupVersionKind,
+		mapping: mapping,
+	}
+}
+
+type dynamicClient struct {
+	resource.Client
+	filter  resource.Filter
+	kind    schema.GroupVersionKind
+	mapping *meta.RESTMapping
+	mu      sync.Mutex
+}
+
+func (c *dynamicClient) getMapping() (*meta.RESTMapping, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mapping == nil {
+		mapping, err := c.RESTMapping(c.kind)
+		if err != nil {
+			return nil, err
+		}
+		c.mapping = mapping
+	}
+	return c.mapping, nil
+}
+
+// resources returns the interface for the client's resource in the given namespace
+func (c *dynamicClient) resources(namespace string) (kubedynamic.ResourceInterface, *meta.RESTMapping, error) {
+	mapping, err := c.getMapping()
+	if err != nil {
+		return nil, nil, err
+	}
+	resources := c.DynamicClient().Resource(mapping.Resource)
+	if isScoped(mapping) {
+		return resources.Namespace(namespace), mapping, nil
+	}
+	return resources, mapping, nil
+}
+
+func (c *dynamicClient) Get(name string) (*Object, error) {
+	mapping, err := c.getMapping()
+	if err != nil {
+		return nil, err
+	}
+	for _, namespace := range resource.GetNamespaces(c.Client, isScoped(mapping)) {
+		object, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if ok, err := c.accept(object); err != nil {
+			return nil, err
+		} else if ok {
+			return NewObject(object, getKind(mapping), c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(mapping.Resource.GroupResource(), name)
+}
+
+func (c *dynamicClient) List(opts ...resource.ListOption) ([]*Object, error) {
+	mapping, err := c.getMapping()
+	if err != nil {
+		return nil, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces
+	options := resource.NewListOptions(opts...)
+	remaining := options.Limit
+	results := make([]*Object, 0)
+	for _, namespace := range resource.GetNamespaces(c.Client, isScoped(mapping)) {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, err := c.list(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			object := item
+			if ok, err := c.accept(&object); err != nil {
+				return nil, err
+			} else if ok {
+				results = append(results, NewObject(&object, getKind(mapping), c.Client))
+			}
+		}
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+// get gets the object with the given name in the given namespace, reading it from the client's cache if it has one
+func (c *dynamicClient) get(namespace, name string) (*unstructured.Unstructured, error) {
+	informer, mapping, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, isScoped(mapping)))
+		if err != nil {
+			return nil, err
+		} else if !exists {
+			return nil, errors.NewNotFound(mapping.Resource.GroupResource(), name)
+		}
+		return object.(*unstructured.Unstructured).DeepCopy(), nil
+	}
+
+	resources, _, err := c.resources(namespace)
+	if err != nil {
+		return nil, err
+	}
+	return resources.Get(name, metav1.GetOptions{})
+}
+
+// list lists the objects in the given namespace, reading them from the client's cache if it has one and the
+// options can be served from it
+func (c *dynamicClient) list(namespace string, options metav1.ListOptions) ([]unstructured.Unstructured, error) {
+	informer, _, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]unstructured.Unstructured, 0, len(objects))
+			for _, object := range objects {
+				item := object.(*unstructured.Unstructured)
+				if selector.Matches(labels.Set(item.GetLabels())) {
+					items = append(items, *item.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].GetName() < items[j].GetName()
+			})
+			return items, nil
+		}
+	}
+
+	resources, _, err := c.resources(namespace)
+	if err != nil {
+		return nil, err
+	}
+	list, err := resources.List(options)
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// informer returns the informer for the client's kind in the given namespace, or nil if the client is not cached
+func (c *dynamicClient) informer(namespace string) (cache.SharedIndexInformer, *meta.RESTMapping, error) {
+	resources, mapping, err := c.resources(namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, mapping, nil
+	}
+	informer, err := cached.Cache().Informer(getKind(mapping), namespace, func() (cache.SharedIndexInformer, error) {
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return resources.List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return resources.Watch(options)
+			},
+		}, &unstructured.Unstructured{}, 0, cache.Indexers{}), nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return informer, mapping, nil
+}
+
+func (c *dynamicClient) Create(object *unstructured.Unstructured) (*Object, error) {
+	namespace := object.GetNamespace()
+	if namespace == "" {
+		namespace = c.Namespace()
+	}
+	resources, mapping, err := c.resources(namespace)
+	if err != nil {
+		return nil, err
+	}
+	object, err = resources.Create(object, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return NewObject(object, getKind(mapping), c.Client), nil
+}
+
+func (c *dynamicClient) Update(object *unstructured.Unstructured) (*Object, error) {
+	namespace := object.GetNamespace()
+	if namespace == "" {
+		namespace = c.Namespace()
+	}
+	current, err := c.get(namespace, object.GetName())
+	if err != nil {
+		return nil, err
+	}
+	resources, mapping, err := c.resources(namespace)
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := c.accept(current); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.NewNotFound(mapping.Resource.GroupResource(), object.GetName())
+	}
+	object, err = resources.Update(object, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return NewObject(object, getKind(mapping), c.Client), nil
+}
+
+func (c *dynamicClient) Patch(name string, patchType types.PatchType, data []byte) (*Object, error) {
+	current, err := c.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	resources, mapping, err := c.resources(current.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	object, err := resources.Patch(name, patchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return NewObject(object, getKind(mapping), c.Client), nil
+}
+
+func (c *dynamicClient) Delete(name string) error {
+	current, err := c.Get(name)
+	if err != nil {
+		return err
+	}
+	resources, _, err := c.resources(current.Namespace)
+	if err != nil {
+		return err
+	}
+	return resources.Delete(name, &metav1.DeleteOptions{})
+}
+
+// accept returns whether the given object passes the client's filter
+func (c *dynamicClient) accept(object *unstructured.Unstructured) (bool, error) {
+	meta, err := GetObjectMeta(object)
+	if err != nil {
+		return false, err
+	}
+	return c.filter(metav1.GroupVersionKind{
+		Group:   c.kind.Group,
+		Version: c.kind.Version,
+		Kind:    c.kind.Kind,
+	}, meta)
+}
+
+func isScoped(mapping *meta.RESTMapping) bool {
+	return mapping.Scope.Name() == meta.RESTScopeNameNamespace
+}
+
+func getKind(mapping *meta.RESTMapping) resource.Kind {
+	return resource.Kind{
+		Group:   mapping.GroupVersionKind.Group,
+		Version: mapping.GroupVersionKind.Version,
+		Kind:    mapping.GroupVersionKind.Kind,
+		Scoped:  isScoped(mapping),
+	}
+}
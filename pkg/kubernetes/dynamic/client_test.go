@@ -0,0 +1,309 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+type testClient struct {
+	*resource.Clientsets
+}
+
+func (c *testClient) Namespace() string {
+	return "test"
+}
+
+var _ resource.Client = &testClient{}
+
+// cachedClient reads objects from a resource cache
+type cachedClient struct {
+	*testClient
+	cache *resource.Cache
+}
+
+func (c *cachedClient) Cache() *resource.Cache {
+	return c.cache
+}
+
+// multiNamespacedClient reads namespaced objects from the test and other namespaces
+type multiNamespacedClient struct {
+	*testClient
+}
+
+func (c *multiNamespacedClient) Namespaces() []string {
+	return []string{"test", "other"}
+}
+
+var databaseKind = schema.GroupVersionKind{
+	Group:   "cloud.atomix.io",
+	Version: "v1beta2",
+	Kind:    "Database",
+}
+
+func newObject(apiVersion, kind, name string) map[string]interface{} {
+	return newNamespacedObject("test", apiVersion, kind, name)
+}
+
+func newNamespacedObject(namespace, apiVersion, kind, name string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"namespace": namespace,
+			"name":      name,
+			"uid":       kind + "-" + namespace + "-" + name,
+		},
+	}
+}
+
+func newList(apiVersion, kind string, names ...string) map[string]interface{} {
+	return newNamespacedList("test", apiVersion, kind, names...)
+}
+
+func newNamespacedList(namespace, apiVersion, kind string, names ...string) map[string]interface{} {
+	items := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		items = append(items, newNamespacedObject(namespace, apiVersion, kind, name))
+	}
+	return map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind + "List",
+		"metadata":   map[string]interface{}{},
+		"items":      items,
+	}
+}
+
+// newDiscoveryServer returns a stand-in for the Kubernetes API server which serves discovery for config maps, nodes
+// and Atomix databases along with a pair of each. A database is also served in the other namespace.
+func newDiscoveryServer(t *testing.T) *httptest.Server {
+	responses := map[string]interface{}{
+		"/api": &metav1.APIVersions{
+			Versions: []string{"v1"},
+		},
+		"/apis": &metav1.APIGroupList{
+			Groups: []metav1.APIGroup{
+				{
+					Name: "cloud.atomix.io",
+					Versions: []metav1.GroupVersionForDiscovery{
+						{GroupVersion: "cloud.atomix.io/v1beta2", Version: "v1beta2"},
+					},
+					PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "cloud.atomix.io/v1beta2", Version: "v1beta2"},
+				},
+			},
+		},
+		"/api/v1": &metav1.APIResourceList{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: []string{"get", "list"}},
+				{Name: "nodes", Namespaced: false, Kind: "Node", Verbs: []string{"get", "list"}},
+				{Name: "pods/log", Namespaced: true, Kind: "Pod", Verbs: []string{"get"}},
+				{Name: "bindings", Namespaced: true, Kind: "Binding", Verbs: []string{"create"}},
+			},
+		},
+		"/apis/cloud.atomix.io/v1beta2": &metav1.APIResourceList{
+			GroupVersion: "cloud.atomix.io/v1beta2",
+			APIResources: []metav1.APIResource{
+				{Name: "databases", Namespaced: true, Kind: "Database", Verbs: []string{"get", "list", "patch", "delete"}},
+			},
+		},
+		"/api/v1/namespaces/test/configmaps":                           newList("v1", "ConfigMap", "foo", "bar"),
+		"/api/v1/namespaces/other/configmaps":                          newNamespacedList("other", "v1", "ConfigMap"),
+		"/api/v1/nodes":                                                newNamespacedList("", "v1", "Node", "foo", "bar"),
+		"/apis/cloud.atomix.io/v1beta2/namespaces/other/databases":     newNamespacedList("other", "cloud.atomix.io/v1beta2", "Database", "foo"),
+		"/apis/cloud.atomix.io/v1beta2/namespaces/other/databases/foo": newNamespacedObject("other", "cloud.atomix.io/v1beta2", "Database", "foo"),
+		"/apis/cloud.atomix.io/v1beta2/namespaces/test/databases":      newList("cloud.atomix.io/v1beta2", "Database", "foo", "bar"),
+		"/apis/cloud.atomix.io/v1beta2/namespaces/test/databases/foo":  newObject("cloud.atomix.io/v1beta2", "Database", "foo"),
+		"/apis/cloud.atomix.io/v1beta2/namespaces/test/databases/bar":  newObject("cloud.atomix.io/v1beta2", "Database", "bar"),
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		response, ok := responses[req.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if req.Method == http.MethodDelete {
+			response = &metav1.Status{Status: metav1.StatusSuccess}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+}
+
+func newTestClient(t *testing.T, server *httptest.Server) *testClient {
+	clientsets, err := resource.NewClientsets(&rest.Config{Host: server.URL}, nil)
+	assert.NoError(t, err)
+	return &testClient{Clientsets: clientsets}
+}
+
+func filterFoo(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
+	return meta.Name == "foo", nil
+}
+
+func TestDynamicClient(t *testing.T) {
+	server := newDiscoveryServer(t)
+	defer server.Close()
+	client := NewClient(newTestClient(t, server), filterFoo, databaseKind)
+
+	database, err := client.Get("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", database.Name)
+	assert.Equal(t, "test", database.Namespace)
+	assert.Equal(t, types.UID("Database-test-foo"), database.UID)
+	assert.True(t, database.Kind.Scoped)
+	assert.Equal(t, "Database", database.Object.GetKind())
+
+	_, err = client.Get("bar")
+	assert.True(t, errors.IsNotFound(err))
+
+	databases, err := client.List()
+	assert.NoError(t, err)
+	assert.Len(t, databases, 1)
+	assert.Equal(t, "foo", databases[0].Name)
+
+	assert.NoError(t, client.Delete("foo"))
+	assert.True(t, errors.IsNotFound(client.Delete("bar")))
+
+	_, err = NewClient(newTestClient(t, server), filterFoo, schema.GroupVersionKind{
+		Group:   "cloud.atomix.io",
+		Version: "v1beta2",
+		Kind:    "Partition",
+	}).Get("foo")
+	assert.Error(t, err)
+}
+
+func TestAll(t *testing.T) {
+	server := newDiscoveryServer(t)
+	defer server.Close()
+
+	objects, err := All(newTestClient(t, server), filterFoo)
+	assert.NoError(t, err)
+	assert.Len(t, objects, 3)
+	kinds := make([]string, 0, len(objects))
+	for _, object := range objects {
+		assert.Equal(t, "foo", object.Name)
+		kinds = append(kinds, object.Object.GetKind())
+	}
+	// Discovery returns the kinds of a group in no particular order
+	assert.ElementsMatch(t, []string{"ConfigMap", "Node", "Database"}, kinds)
+
+	objects, err = All(newTestClient(t, server), resource.NoFilter)
+	assert.NoError(t, err)
+	assert.Len(t, objects, 6)
+
+	objects, err = All(&multiNamespacedClient{newTestClient(t, server)}, resource.NoFilter)
+	assert.NoError(t, err)
+	assert.Len(t, objects, 7)
+}
+
+func TestDynamicClientNamespaces(t *testing.T) {
+	server := newDiscoveryServer(t)
+	defer server.Close()
+	client := NewClient(&multiNamespacedClient{newTestClient(t, server)}, resource.NoFilter, databaseKind)
+
+	databases, err := client.List()
+	assert.NoError(t, err)
+	assert.Len(t, databases, 3)
+	assert.Equal(t, "other", databases[2].Namespace)
+
+	// Objects are read from the first namespace that contains them
+	database, err := client.Get("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "test", database.Namespace)
+
+	// Objects are updated in their own namespace rather than the first namespace that contains their name
+	database, err = client.Update(&unstructured.Unstructured{Object: newNamespacedObject("other", "cloud.atomix.io/v1beta2", "Database", "foo")})
+	assert.NoError(t, err)
+	assert.Equal(t, "other", database.Namespace)
+
+	otherFilter := func(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
+		return meta.Namespace == "other", nil
+	}
+	client = NewClient(&multiNamespacedClient{newTestClient(t, server)}, otherFilter, databaseKind)
+	database, err = client.Get("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "other", database.Namespace)
+	assert.NoError(t, client.Delete("foo"))
+
+	nodes, err := NewClient(&multiNamespacedClient{newTestClient(t, server)}, resource.NoFilter, schema.GroupVersionKind{
+		Version: "v1",
+		Kind:    "Node",
+	}).List()
+	assert.NoError(t, err)
+	assert.Len(t, nodes, 2)
+	assert.False(t, nodes[0].Kind.Scoped)
+	assert.Equal(t, "", nodes[0].Namespace)
+}
+
+func TestDynamicClientCache(t *testing.T) {
+	server := newDiscoveryServer(t)
+	defer server.Close()
+
+	// Count the database reads and hold watches open until the test completes
+	var requests int32
+	done := make(chan struct{})
+	defer close(done)
+	handler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("watch") == "true" {
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			select {
+			case <-req.Context().Done():
+			case <-done:
+			}
+			return
+		}
+		if strings.Contains(req.URL.Path, "/databases") {
+			atomic.AddInt32(&requests, 1)
+		}
+		handler.ServeHTTP(w, req)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := NewClient(&cachedClient{testClient: newTestClient(t, server), cache: resource.NewCache(ctx)}, filterFoo, databaseKind)
+
+	database, err := client.Get("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", database.Name)
+	assert.Equal(t, types.UID("Database-test-foo"), database.UID)
+
+	_, err = client.Get("bar")
+	assert.True(t, errors.IsNotFound(err))
+	_, err = client.Get("baz")
+	assert.True(t, errors.IsNotFound(err))
+
+	databases, err := client.List()
+	assert.NoError(t, err)
+	assert.Len(t, databases, 1)
+
+	// All reads are served by the informer's initial list
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
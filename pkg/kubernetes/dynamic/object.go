@@ -0,0 +1,53 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewObject returns a new unstructured object of the given kind
+func NewObject(object *unstructured.Unstructured, kind resource.Kind, client resource.Client) *Object {
+	return &Object{
+		Resource: resource.NewResource(metav1.ObjectMeta{
+			Namespace: object.GetNamespace(),
+			Name:      object.GetName(),
+			UID:       object.GetUID(),
+		}, kind, client),
+		Object: object,
+	}
+}
+
+// Object is an unstructured Kubernetes object
+type Object struct {
+	*resource.Resource
+	Object *unstructured.Unstructured
+}
+
+// GetObjectMeta returns the metadata of the given unstructured object
+func GetObjectMeta(object *unstructured.Unstructured) (metav1.ObjectMeta, error) {
+	meta := metav1.ObjectMeta{}
+	metadata, ok := object.Object["metadata"].(map[string]interface{})
+	if !ok {
+		return meta, nil
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(metadata, &meta); err != nil {
+		return meta, err
+	}
+	return meta, nil
+}
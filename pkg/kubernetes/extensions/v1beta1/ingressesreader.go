@@ -3,18 +3,31 @@
 package v1beta1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type IngressesReader interface {
 	Get(name string) (*Ingress, error)
-	List() ([]*Ingress, error)
+	List(opts ...resource.ListOption) ([]*Ingress, error)
+	ListPages(handler func([]*Ingress) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan IngressEvent, error)
+	WaitFor(ctx context.Context, predicate func(IngressEvent) (bool, error)) (IngressEvent, error)
+}
+
+type IngressEvent struct {
+	Type    resource.EventType
+	Ingress *Ingress
 }
 
 func NewIngressesReader(client resource.Client, filter resource.Filter) IngressesReader {
@@ -30,24 +43,13 @@ type ingressesReader struct {
 }
 
 func (c *ingressesReader) Get(name string) (*Ingress, error) {
-	ingress := &extensionsv1beta1.Ingress{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.ExtensionsV1beta1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), IngressKind.Scoped).
-		Resource(IngressResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(ingress)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, IngressKind.Scoped) {
+		ingress, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   IngressKind.Group,
 			Version: IngressKind.Version,
@@ -55,37 +57,123 @@ func (c *ingressesReader) Get(name string) (*Ingress, error) {
 		}, ingress.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewIngress(ingress, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    IngressKind.Group,
+		Resource: IngressResource.Name,
+	}, name)
+}
+
+func (c *ingressesReader) get(namespace, name string) (*extensionsv1beta1.Ingress, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, IngressKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    IngressKind.Group,
 				Resource: IngressResource.Name,
 			}, name)
 		}
+		return object.(*extensionsv1beta1.Ingress).DeepCopy(), nil
 	}
-	return NewIngress(ingress, c.Client), nil
-}
 
-func (c *ingressesReader) List() ([]*Ingress, error) {
-	list := &extensionsv1beta1.IngressList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &extensionsv1beta1.Ingress{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.ExtensionsV1beta1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), IngressKind.Scoped).
+		NamespaceIfScoped(namespace, IngressKind.Scoped).
 		Resource(IngressResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*Ingress, 0, len(list.Items))
-	for _, ingress := range list.Items {
+func (c *ingressesReader) List(opts ...resource.ListOption) ([]*Ingress, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, IngressKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*Ingress, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *ingressesReader) ListPages(handler func([]*Ingress) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, IngressKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *ingressesReader) list(namespace string, options metav1.ListOptions) ([]*Ingress, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *ingressesReader) filterItems(items []extensionsv1beta1.Ingress) ([]*Ingress, error) {
+	results := make([]*Ingress, 0, len(items))
+	for _, ingress := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   IngressKind.Group,
 			Version: IngressKind.Version,
@@ -100,3 +188,145 @@ func (c *ingressesReader) List() ([]*Ingress, error) {
 	}
 	return results, nil
 }
+
+func (c *ingressesReader) listItems(namespace string, options metav1.ListOptions) ([]extensionsv1beta1.Ingress, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]extensionsv1beta1.Ingress, 0, len(objects))
+			for _, object := range objects {
+				ingress := object.(*extensionsv1beta1.Ingress)
+				if selector.Matches(labels.Set(ingress.Labels)) {
+					items = append(items, *ingress.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &extensionsv1beta1.IngressList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, IngressKind.Scoped).
+		Resource(IngressResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *ingressesReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(IngressKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &extensionsv1beta1.IngressList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, IngressKind.Scoped).
+					Resource(IngressResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, IngressKind.Scoped).
+					Resource(IngressResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &extensionsv1beta1.Ingress{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *ingressesReader) Watch(ctx context.Context) (<-chan IngressEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, IngressKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, IngressKind.Scoped).
+			Resource(IngressResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan IngressEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			ingress, ok := watchEvent.Object.(*extensionsv1beta1.Ingress)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   IngressKind.Group,
+				Version: IngressKind.Version,
+				Kind:    IngressKind.Kind,
+			}, ingress.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- IngressEvent{Type: watchEvent.Type, Ingress: NewIngress(ingress, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *ingressesReader) WaitFor(ctx context.Context, predicate func(IngressEvent) (bool, error)) (IngressEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return IngressEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return IngressEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return IngressEvent{}, ctx.Err()
+}
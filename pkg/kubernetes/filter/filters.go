@@ -3,850 +3,509 @@
 package filter
 
 import (
-	admissionregistrationv1 "github.com/onosproject/helm-go/pkg/kubernetes/admissionregistration/v1"
-	apiextensionsv1 "github.com/onosproject/helm-go/pkg/kubernetes/apiextensions/v1"
-	apiextensionsv1beta1 "github.com/onosproject/helm-go/pkg/kubernetes/apiextensions/v1beta1"
-	appsv1 "github.com/onosproject/helm-go/pkg/kubernetes/apps/v1"
-	appsv1beta1 "github.com/onosproject/helm-go/pkg/kubernetes/apps/v1beta1"
-	batchv1 "github.com/onosproject/helm-go/pkg/kubernetes/batch/v1"
-	batchv1beta1 "github.com/onosproject/helm-go/pkg/kubernetes/batch/v1beta1"
-	batchv2alpha1 "github.com/onosproject/helm-go/pkg/kubernetes/batch/v2alpha1"
-	corev1 "github.com/onosproject/helm-go/pkg/kubernetes/core/v1"
-	extensionsv1beta1 "github.com/onosproject/helm-go/pkg/kubernetes/extensions/v1beta1"
-	networkingv1beta1 "github.com/onosproject/helm-go/pkg/kubernetes/networking/v1beta1"
-	policyv1beta1 "github.com/onosproject/helm-go/pkg/kubernetes/policy/v1beta1"
-	rbacv1 "github.com/onosproject/helm-go/pkg/kubernetes/rbac/v1"
+	"fmt"
+	"github.com/onosproject/helm-go/pkg/kubernetes/dynamic"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
-	storagev1 "github.com/onosproject/helm-go/pkg/kubernetes/storage/v1"
 	helmkube "helm.sh/helm/v3/pkg/kube"
 	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
-func Resources(client resource.Client, resources helmkube.ResourceList) resource.Filter {
+// MaxOwnerDepth is the maximum length of the chain of owner references followed to find a release resource
+const MaxOwnerDepth = 10
+
+// OwnerCacheTTL is the time for which an owner found to be part of a release, or a prefetched list of owners, is
+// cached
+var OwnerCacheTTL = 10 * time.Second
+
+const (
+	helmManagedByLabel             = "app.kubernetes.io/managed-by"
+	helmManagedByValue             = "Helm"
+	helmReleaseNameAnnotation      = "meta.helm.sh/release-name"
+	helmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+)
+
+const (
+	// PodTemplateHashLabel is the label with which deployments label their replica sets and pods
+	PodTemplateHashLabel = "pod-template-hash"
+	// ServiceNameLabel is the label with which endpoint slices are labeled with the name of their service
+	ServiceNameLabel = "kubernetes.io/service-name"
+)
+
+var (
+	serviceKind     = schema.GroupVersionKind{Version: "v1", Kind: "Service"}
+	replicaSetKind  = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"}
+	statefulSetKind = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}
+)
+
+// Option is a release filter option
+type Option func(*options)
+
+type options struct {
+	owners   *OwnerCache
+	prefetch []schema.GroupVersionKind
+	release  *helmRelease
+}
+
+type helmRelease struct {
+	name      string
+	namespace string
+}
+
+// WithHelmLabels returns an option that accepts resources labeled as managed by Helm and annotated with the given
+// release name and namespace, in addition to the resources in the release manifest. Helm 3.2 and later label and
+// annotate the resources it installs.
+func WithHelmLabels(name, namespace string) Option {
+	return func(options *options) {
+		options.release = &helmRelease{
+			name:      name,
+			namespace: namespace,
+		}
+	}
+}
+
+// WithOwnerCache returns an option that shares the given owner cache between filters
+func WithOwnerCache(owners *OwnerCache) Option {
+	return func(options *options) {
+		options.owners = owners
+	}
+}
+
+// WithPrefetch returns an option that lists all the objects of the given owner kinds in a namespace the first
+// time an owner of the kind is resolved, rather than getting owners one at a time
+func WithPrefetch(kinds ...schema.GroupVersionKind) Option {
+	return func(options *options) {
+		options.prefetch = append(options.prefetch, kinds...)
+	}
+}
+
+// Resources returns a filter that accepts the given release resources and the objects they own. Owners found to be
+// part of the release are cached by UID for OwnerCacheTTL, so listing many objects with the same owners resolves each
+// owner once. Owners that are not found or not part of the release are not cached, since an owner that is not yet
+// visible, e.g. a new replica set, may be found on the next call.
+func Resources(client resource.Client, resources helmkube.ResourceList, opts ...Option) resource.Filter {
+	options := options{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.owners == nil {
+		options.owners = NewOwnerCache()
+	}
+	prefetch := make(map[schema.GroupVersionKind]bool)
+	for _, kind := range options.prefetch {
+		prefetch[kind] = true
+	}
+	filter := &releaseFilter{
+		client:   client,
+		manifest: newManifest(resources),
+		owners:   options.owners,
+		prefetch: prefetch,
+		release:  options.release,
+	}
 	return func(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
-		return filterResources(client, resources, kind, meta)
+		return filter.filterResource(kind, meta, newFilterCall(), 0)
 	}
 }
 
-func filterResources(client resource.Client, resources helmkube.ResourceList, kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
-	for _, resource := range resources {
-		resourceKind := resource.Object.GetObjectKind().GroupVersionKind()
-		if resourceKind.Group == kind.Group &&
-			resourceKind.Version == kind.Version &&
-			resourceKind.Kind == kind.Kind &&
-			resource.Namespace == meta.Namespace &&
-			resource.Name == meta.Name {
-			return true, nil
+// NewOwnerCache returns a new cache of owners whose entries expire after OwnerCacheTTL
+func NewOwnerCache() *OwnerCache {
+	return &OwnerCache{
+		owners:     make(map[types.UID]time.Time),
+		objects:    make(map[types.UID]prefetchedObject),
+		prefetched: make(map[string]time.Time),
+		ttl:        OwnerCacheTTL,
+		now:        time.Now,
+	}
+}
+
+// OwnerCache caches the UIDs of owners that are part of a release, along with the metadata of prefetched owners
+type OwnerCache struct {
+	owners     map[types.UID]time.Time
+	objects    map[types.UID]prefetchedObject
+	prefetched map[string]time.Time
+	ttl        time.Duration
+	now        func() time.Time
+	mu         sync.RWMutex
+}
+
+// prefetchedObject is the metadata of a prefetched owner along with the time at which it expires
+type prefetchedObject struct {
+	meta    metav1.ObjectMeta
+	expires time.Time
+}
+
+// isReleaseOwner returns whether the owner with the given UID is cached as part of the release
+func (c *OwnerCache) isReleaseOwner(uid types.UID) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	expires, ok := c.owners[uid]
+	return ok && c.now().Before(expires)
+}
+
+// addReleaseOwner caches the owner with the given UID as part of the release
+func (c *OwnerCache) addReleaseOwner(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.owners[uid] = c.now().Add(c.ttl)
+}
+
+func (c *OwnerCache) getObject(uid types.UID) (metav1.ObjectMeta, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	object, ok := c.objects[uid]
+	if !ok || !c.now().Before(object.expires) {
+		return metav1.ObjectMeta{}, false
+	}
+	return object.meta, true
+}
+
+// prefetch lists the objects of the given client's kind in the given namespace if they have not been listed within
+// the cache TTL
+func (c *OwnerCache) prefetch(kind schema.GroupVersionKind, namespace string, client dynamic.Client) error {
+	key := fmt.Sprintf("%s/%s", kind.String(), namespace)
+	c.mu.RLock()
+	expires, prefetched := c.prefetched[key]
+	c.mu.RUnlock()
+	if prefetched && c.now().Before(expires) {
+		return nil
+	}
+
+	objects, err := client.List()
+	if err != nil {
+		return err
+	}
+	metas := make([]metav1.ObjectMeta, 0, len(objects))
+	for _, object := range objects {
+		meta, err := dynamic.GetObjectMeta(object.Object)
+		if err != nil {
+			return err
+		}
+		metas = append(metas, meta)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expires = c.now().Add(c.ttl)
+	for uid, object := range c.objects {
+		if !c.now().Before(object.expires) {
+			delete(c.objects, uid)
 		}
 	}
-	return filterOwners(client, resources, kind, meta)
+	for _, meta := range metas {
+		c.objects[meta.UID] = prefetchedObject{meta: meta, expires: expires}
+	}
+	c.prefetched[key] = expires
+	return nil
+}
+
+type manifestKey struct {
+	apiVersion string
+	kind       string
+	namespace  string
+	name       string
+}
+
+// manifest is an index of the resources in a release manifest
+type manifest map[manifestKey]bool
+
+func newManifest(resources helmkube.ResourceList) manifest {
+	manifest := make(manifest)
+	for _, resource := range resources {
+		kind := resource.Object.GetObjectKind().GroupVersionKind()
+		manifest[manifestKey{
+			apiVersion: kind.GroupVersion().String(),
+			kind:       kind.Kind,
+			namespace:  resource.Namespace,
+			name:       resource.Name,
+		}] = true
+	}
+	return manifest
+}
+
+func (m manifest) contains(apiVersion, kind, namespace, name string) bool {
+	return m[manifestKey{
+		apiVersion: apiVersion,
+		kind:       kind,
+		namespace:  namespace,
+		name:       name,
+	}]
+}
+
+// filterCall is the state of a single call to the filter
+type filterCall struct {
+	visited map[types.UID]bool
+}
+
+func newFilterCall() *filterCall {
+	return &filterCall{
+		visited: make(map[types.UID]bool),
+	}
+}
+
+type releaseFilter struct {
+	client   resource.Client
+	manifest manifest
+	owners   *OwnerCache
+	prefetch map[schema.GroupVersionKind]bool
+	release  *helmRelease
+}
+
+// isReleaseResource returns whether the given object is a resource in the release manifest or, in Helm label mode,
+// is labeled with the release
+func (f *releaseFilter) isReleaseResource(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) bool {
+	apiVersion := schema.GroupVersion{Group: kind.Group, Version: kind.Version}.String()
+	if f.manifest.contains(apiVersion, kind.Kind, meta.Namespace, meta.Name) {
+		return true
+	}
+	return f.release != nil &&
+		meta.Labels[helmManagedByLabel] == helmManagedByValue &&
+		meta.Annotations[helmReleaseNameAnnotation] == f.release.name &&
+		meta.Annotations[helmReleaseNamespaceAnnotation] == f.release.namespace
 }
 
-func filterOwners(client resource.Client, resources helmkube.ResourceList, kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
+func (f *releaseFilter) filterResource(kind metav1.GroupVersionKind, meta metav1.ObjectMeta, call *filterCall, depth int) (bool, error) {
+	if f.isReleaseResource(kind, meta) {
+		return true, nil
+	}
+	if meta.UID != "" {
+		call.visited[meta.UID] = true
+	}
 	for _, owner := range meta.OwnerReferences {
-		ok, err := filterOwner(client, resources, owner)
+		ok, err := f.filterOwner(meta.Namespace, owner, call, depth+1)
 		if ok {
 			return true, nil
 		} else if err != nil {
 			return false, err
 		}
 	}
-	return filterApp(client, resources, kind, meta)
+	if metav1.GetControllerOf(&meta) != nil {
+		return false, nil
+	}
+	return f.filterRelated(kind, meta, call, depth+1)
 }
 
-func filterOwner(client resource.Client, resources helmkube.ResourceList, owner metav1.OwnerReference) (bool, error) {
-	for _, resource := range resources {
-		resourceKind := resource.Object.GetObjectKind().GroupVersionKind()
-		if resourceKind.Kind == owner.Kind &&
-			resourceKind.GroupVersion().String() == owner.APIVersion &&
-			resource.Name == owner.Name {
-			return true, nil
-		}
-	}
+// Related is an object to which an object created without a controller is related by a Kubernetes naming or
+// labeling convention
+type Related struct {
+	// Kind is the kind of the related object
+	Kind schema.GroupVersionKind
+	// Name is the name of the related object, or empty if the related object is matched by its labels
+	Name string
+	// Labels are the labels of the related object if it is matched by its labels
+	Labels labels.Set
+}
 
-	switch owner.APIVersion {
-	case "admissionregistration.k8s.io/v1":
-		switch owner.Kind {
-		case "MutatingWebhookConfiguration":
-			mutatingWebhookConfigurationClient := admissionregistrationv1.NewMutatingWebhookConfigurationsReader(client, resource.NoFilter)
-			mutatingWebhookConfiguration, err := mutatingWebhookConfigurationClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   admissionregistrationv1.MutatingWebhookConfigurationKind.Group,
-					Version: admissionregistrationv1.MutatingWebhookConfigurationKind.Version,
-					Kind:    admissionregistrationv1.MutatingWebhookConfigurationKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, mutatingWebhookConfiguration.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "ValidatingWebhookConfiguration":
-			validatingWebhookConfigurationClient := admissionregistrationv1.NewValidatingWebhookConfigurationsReader(client, resource.NoFilter)
-			validatingWebhookConfiguration, err := validatingWebhookConfigurationClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   admissionregistrationv1.ValidatingWebhookConfigurationKind.Group,
-					Version: admissionregistrationv1.ValidatingWebhookConfigurationKind.Version,
-					Kind:    admissionregistrationv1.ValidatingWebhookConfigurationKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, validatingWebhookConfiguration.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		}
-	case "apiextensions.k8s.io/v1":
-		switch owner.Kind {
-		case "CustomResourceDefinition":
-			customResourceDefinitionClient := apiextensionsv1.NewCustomResourceDefinitionsReader(client, resource.NoFilter)
-			customResourceDefinition, err := customResourceDefinitionClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   apiextensionsv1.CustomResourceDefinitionKind.Group,
-					Version: apiextensionsv1.CustomResourceDefinitionKind.Version,
-					Kind:    apiextensionsv1.CustomResourceDefinitionKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, customResourceDefinition.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		}
-	case "apiextensions.k8s.io/v1beta1":
-		switch owner.Kind {
-		case "CustomResourceDefinition":
-			customResourceDefinitionClient := apiextensionsv1beta1.NewCustomResourceDefinitionsReader(client, resource.NoFilter)
-			customResourceDefinition, err := customResourceDefinitionClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   apiextensionsv1beta1.CustomResourceDefinitionKind.Group,
-					Version: apiextensionsv1beta1.CustomResourceDefinitionKind.Version,
-					Kind:    apiextensionsv1beta1.CustomResourceDefinitionKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, customResourceDefinition.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		}
-	case "apps/v1":
-		switch owner.Kind {
-		case "DaemonSet":
-			daemonSetClient := appsv1.NewDaemonSetsReader(client, resource.NoFilter)
-			daemonSet, err := daemonSetClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   appsv1.DaemonSetKind.Group,
-					Version: appsv1.DaemonSetKind.Version,
-					Kind:    appsv1.DaemonSetKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, daemonSet.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "Deployment":
-			deploymentClient := appsv1.NewDeploymentsReader(client, resource.NoFilter)
-			deployment, err := deploymentClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   appsv1.DeploymentKind.Group,
-					Version: appsv1.DeploymentKind.Version,
-					Kind:    appsv1.DeploymentKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, deployment.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "ReplicaSet":
-			replicaSetClient := appsv1.NewReplicaSetsReader(client, resource.NoFilter)
-			replicaSet, err := replicaSetClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   appsv1.ReplicaSetKind.Group,
-					Version: appsv1.ReplicaSetKind.Version,
-					Kind:    appsv1.ReplicaSetKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, replicaSet.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "StatefulSet":
-			statefulSetClient := appsv1.NewStatefulSetsReader(client, resource.NoFilter)
-			statefulSet, err := statefulSetClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   appsv1.StatefulSetKind.Group,
-					Version: appsv1.StatefulSetKind.Version,
-					Kind:    appsv1.StatefulSetKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, statefulSet.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		}
-	case "apps/v1beta1":
-		switch owner.Kind {
-		case "Deployment":
-			deploymentClient := appsv1beta1.NewDeploymentsReader(client, resource.NoFilter)
-			deployment, err := deploymentClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   appsv1beta1.DeploymentKind.Group,
-					Version: appsv1beta1.DeploymentKind.Version,
-					Kind:    appsv1beta1.DeploymentKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, deployment.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "StatefulSet":
-			statefulSetClient := appsv1beta1.NewStatefulSetsReader(client, resource.NoFilter)
-			statefulSet, err := statefulSetClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   appsv1beta1.StatefulSetKind.Group,
-					Version: appsv1beta1.StatefulSetKind.Version,
-					Kind:    appsv1beta1.StatefulSetKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, statefulSet.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
+// GetRelated returns the object to which the given object is related by convention: endpoints share the name of
+// their service, endpoint slices are labeled with the name of their service, and the pods of a deployment are
+// labeled with the pod template hash of their replica set. Replica sets are only related to their deployment by
+// their controller reference. Persistent volume claims are related to stateful sets by IsVolumeClaimOf.
+func GetRelated(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (Related, bool) {
+	switch {
+	case kind.Group == "" && kind.Kind == "Endpoints":
+		return Related{Kind: serviceKind, Name: meta.Name}, true
+	case kind.Group == "discovery.k8s.io" && kind.Kind == "EndpointSlice":
+		if name, ok := meta.Labels[ServiceNameLabel]; ok {
+			return Related{Kind: serviceKind, Name: name}, true
 		}
-	case "batch/v1":
-		switch owner.Kind {
-		case "Job":
-			jobClient := batchv1.NewJobsReader(client, resource.NoFilter)
-			job, err := jobClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   batchv1.JobKind.Group,
-					Version: batchv1.JobKind.Version,
-					Kind:    batchv1.JobKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, job.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
+	case kind.Group == "" && kind.Kind == "Pod":
+		if hash, ok := meta.Labels[PodTemplateHashLabel]; ok {
+			return Related{Kind: replicaSetKind, Labels: labels.Set{PodTemplateHashLabel: hash}}, true
 		}
-	case "batch/v1beta1":
-		switch owner.Kind {
-		case "CronJob":
-			cronJobClient := batchv1beta1.NewCronJobsReader(client, resource.NoFilter)
-			cronJob, err := cronJobClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   batchv1beta1.CronJobKind.Group,
-					Version: batchv1beta1.CronJobKind.Version,
-					Kind:    batchv1beta1.CronJobKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, cronJob.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
+	}
+	return Related{}, false
+}
+
+// IsVolumeClaimOf returns whether the claim with the given name was created from a volume claim template of the
+// given stateful set. Stateful sets name their claims <template>-<statefulset>-<ordinal>.
+func IsVolumeClaimOf(name string, statefulSet *unstructured.Unstructured) bool {
+	prefix, ok := getVolumeClaimPrefix(name)
+	if !ok || !strings.HasSuffix(prefix, "-"+statefulSet.GetName()) {
+		return false
+	}
+	template := strings.TrimSuffix(prefix, "-"+statefulSet.GetName())
+	templates, _, _ := unstructured.NestedSlice(statefulSet.Object, "spec", "volumeClaimTemplates")
+	for _, t := range templates {
+		if t, ok := t.(map[string]interface{}); ok {
+			if templateName, _, _ := unstructured.NestedString(t, "metadata", "name"); templateName == template {
+				return true
 			}
 		}
-	case "batch/v2alpha1":
-		switch owner.Kind {
-		case "CronJob":
-			cronJobClient := batchv2alpha1.NewCronJobsReader(client, resource.NoFilter)
-			cronJob, err := cronJobClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   batchv2alpha1.CronJobKind.Group,
-					Version: batchv2alpha1.CronJobKind.Version,
-					Kind:    batchv2alpha1.CronJobKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, cronJob.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
+	}
+	return false
+}
+
+// getVolumeClaimPrefix returns the given claim name without its ordinal suffix, or false if it has none
+func getVolumeClaimPrefix(name string) (string, bool) {
+	i := strings.LastIndex(name, "-")
+	if i == -1 {
+		return "", false
+	}
+	if _, err := strconv.Atoi(name[i+1:]); err != nil {
+		return "", false
+	}
+	return name[:i], true
+}
+
+// filterRelated returns whether an object without a controller is related by convention to a release resource
+func (f *releaseFilter) filterRelated(kind metav1.GroupVersionKind, meta metav1.ObjectMeta, call *filterCall, depth int) (bool, error) {
+	if depth > MaxOwnerDepth {
+		return false, nil
+	}
+	if kind.Group == "" && kind.Kind == "PersistentVolumeClaim" {
+		return f.filterVolumeClaim(meta, call, depth)
+	}
+	related, ok := GetRelated(kind, meta)
+	if !ok {
+		return false, nil
+	}
+	if related.Name != "" {
+		return f.filterObject(related.Kind, meta.Namespace, related.Name, call, depth)
+	}
+	return f.filterLabeled(related.Kind, meta.Namespace, related.Labels, call, depth)
+}
+
+// filterVolumeClaim returns whether the given claim was created from a volume claim template of a release stateful
+// set. The claim is matched against each stateful set in its namespace.
+func (f *releaseFilter) filterVolumeClaim(meta metav1.ObjectMeta, call *filterCall, depth int) (bool, error) {
+	if _, ok := getVolumeClaimPrefix(meta.Name); !ok {
+		return false, nil
+	}
+	client := dynamic.NewClient(resource.ForNamespace(f.client, meta.Namespace), resource.NoFilter, statefulSetKind)
+	statefulSets, err := client.List()
+	if errors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	for _, statefulSet := range statefulSets {
+		if statefulSet.Namespace != meta.Namespace || !IsVolumeClaimOf(meta.Name, statefulSet.Object) {
+			continue
 		}
-	case "extensions/v1beta1":
-		switch owner.Kind {
-		case "Ingress":
-			ingressClient := extensionsv1beta1.NewIngressesReader(client, resource.NoFilter)
-			ingress, err := ingressClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   extensionsv1beta1.IngressKind.Group,
-					Version: extensionsv1beta1.IngressKind.Version,
-					Kind:    extensionsv1beta1.IngressKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, ingress.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
+		statefulSetMeta, err := dynamic.GetObjectMeta(statefulSet.Object)
+		if err != nil {
+			return false, err
 		}
-	case "networking/v1beta1":
-		switch owner.Kind {
-		case "Ingress":
-			ingressClient := networkingv1beta1.NewIngressesReader(client, resource.NoFilter)
-			ingress, err := ingressClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   networkingv1beta1.IngressKind.Group,
-					Version: networkingv1beta1.IngressKind.Version,
-					Kind:    networkingv1beta1.IngressKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, ingress.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
+		ok, err := f.filterResource(metav1.GroupVersionKind(statefulSetKind), statefulSetMeta, call, depth)
+		if ok || err != nil {
+			return ok, err
 		}
-	case "policy/v1beta1":
-		switch owner.Kind {
-		case "PodDisruptionBudget":
-			podDisruptionBudgetClient := policyv1beta1.NewPodDisruptionBudgetsReader(client, resource.NoFilter)
-			podDisruptionBudget, err := podDisruptionBudgetClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   policyv1beta1.PodDisruptionBudgetKind.Group,
-					Version: policyv1beta1.PodDisruptionBudgetKind.Version,
-					Kind:    policyv1beta1.PodDisruptionBudgetKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, podDisruptionBudget.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "PodSecurityPolicy":
-			podSecurityPolicyClient := policyv1beta1.NewPodSecurityPoliciesReader(client, resource.NoFilter)
-			podSecurityPolicy, err := podSecurityPolicyClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   policyv1beta1.PodSecurityPolicyKind.Group,
-					Version: policyv1beta1.PodSecurityPolicyKind.Version,
-					Kind:    policyv1beta1.PodSecurityPolicyKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, podSecurityPolicy.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
+	}
+	return false, nil
+}
+
+// filterLabeled returns whether any object of the given kind with the given labels is part of the release
+func (f *releaseFilter) filterLabeled(kind schema.GroupVersionKind, namespace string, set labels.Set, call *filterCall, depth int) (bool, error) {
+	client := dynamic.NewClient(resource.ForNamespace(f.client, namespace), resource.NoFilter, kind)
+	objects, err := client.List(resource.WithLabelSelector(set.String()))
+	if errors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	for _, object := range objects {
+		if object.Namespace != namespace {
+			continue
 		}
-	case "rbac.authorization.k8s.io/v1":
-		switch owner.Kind {
-		case "ClusterRole":
-			clusterRoleClient := rbacv1.NewClusterRolesReader(client, resource.NoFilter)
-			clusterRole, err := clusterRoleClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   rbacv1.ClusterRoleKind.Group,
-					Version: rbacv1.ClusterRoleKind.Version,
-					Kind:    rbacv1.ClusterRoleKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, clusterRole.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "ClusterRoleBinding":
-			clusterRoleBindingClient := rbacv1.NewClusterRoleBindingsReader(client, resource.NoFilter)
-			clusterRoleBinding, err := clusterRoleBindingClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   rbacv1.ClusterRoleBindingKind.Group,
-					Version: rbacv1.ClusterRoleBindingKind.Version,
-					Kind:    rbacv1.ClusterRoleBindingKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, clusterRoleBinding.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "Role":
-			roleClient := rbacv1.NewRolesReader(client, resource.NoFilter)
-			role, err := roleClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   rbacv1.RoleKind.Group,
-					Version: rbacv1.RoleKind.Version,
-					Kind:    rbacv1.RoleKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, role.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "RoleBinding":
-			roleBindingClient := rbacv1.NewRoleBindingsReader(client, resource.NoFilter)
-			roleBinding, err := roleBindingClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   rbacv1.RoleBindingKind.Group,
-					Version: rbacv1.RoleBindingKind.Version,
-					Kind:    rbacv1.RoleBindingKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, roleBinding.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
+		meta, err := dynamic.GetObjectMeta(object.Object)
+		if err != nil {
+			return false, err
 		}
-	case "storage.k8s.io/v1":
-		switch owner.Kind {
-		case "StorageClass":
-			storageClassClient := storagev1.NewStorageClassesReader(client, resource.NoFilter)
-			storageClass, err := storageClassClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   storagev1.StorageClassKind.Group,
-					Version: storagev1.StorageClassKind.Version,
-					Kind:    storagev1.StorageClassKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, storageClass.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		}
-	case "/v1":
-		switch owner.Kind {
-		case "ConfigMap":
-			configMapClient := corev1.NewConfigMapsReader(client, resource.NoFilter)
-			configMap, err := configMapClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   corev1.ConfigMapKind.Group,
-					Version: corev1.ConfigMapKind.Version,
-					Kind:    corev1.ConfigMapKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, configMap.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "Endpoints":
-			endpointsClient := corev1.NewEndpointsReader(client, resource.NoFilter)
-			endpoints, err := endpointsClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   corev1.EndpointsKind.Group,
-					Version: corev1.EndpointsKind.Version,
-					Kind:    corev1.EndpointsKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, endpoints.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "Namespace":
-			namespaceClient := corev1.NewNamespacesReader(client, resource.NoFilter)
-			namespace, err := namespaceClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   corev1.NamespaceKind.Group,
-					Version: corev1.NamespaceKind.Version,
-					Kind:    corev1.NamespaceKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, namespace.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "Node":
-			nodeClient := corev1.NewNodesReader(client, resource.NoFilter)
-			node, err := nodeClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   corev1.NodeKind.Group,
-					Version: corev1.NodeKind.Version,
-					Kind:    corev1.NodeKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, node.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "PersistentVolume":
-			persistentVolumeClient := corev1.NewPersistentVolumesReader(client, resource.NoFilter)
-			persistentVolume, err := persistentVolumeClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   corev1.PersistentVolumeKind.Group,
-					Version: corev1.PersistentVolumeKind.Version,
-					Kind:    corev1.PersistentVolumeKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, persistentVolume.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "PersistentVolumeClaim":
-			persistentVolumeClaimClient := corev1.NewPersistentVolumeClaimsReader(client, resource.NoFilter)
-			persistentVolumeClaim, err := persistentVolumeClaimClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   corev1.PersistentVolumeClaimKind.Group,
-					Version: corev1.PersistentVolumeClaimKind.Version,
-					Kind:    corev1.PersistentVolumeClaimKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, persistentVolumeClaim.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "Pod":
-			podClient := corev1.NewPodsReader(client, resource.NoFilter)
-			pod, err := podClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   corev1.PodKind.Group,
-					Version: corev1.PodKind.Version,
-					Kind:    corev1.PodKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, pod.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "PodTemplate":
-			podTemplateClient := corev1.NewPodTemplatesReader(client, resource.NoFilter)
-			podTemplate, err := podTemplateClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   corev1.PodTemplateKind.Group,
-					Version: corev1.PodTemplateKind.Version,
-					Kind:    corev1.PodTemplateKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, podTemplate.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "Secret":
-			secretClient := corev1.NewSecretsReader(client, resource.NoFilter)
-			secret, err := secretClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   corev1.SecretKind.Group,
-					Version: corev1.SecretKind.Version,
-					Kind:    corev1.SecretKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, secret.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
-		case "Service":
-			serviceClient := corev1.NewServicesReader(client, resource.NoFilter)
-			service, err := serviceClient.Get(owner.Name)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   corev1.ServiceKind.Group,
-					Version: corev1.ServiceKind.Version,
-					Kind:    corev1.ServiceKind.Kind,
-				}
-				ok, err := filterResources(client, resources, groupVersionKind, service.Object.ObjectMeta)
-				if ok {
-					return true, nil
-				} else if err != nil {
-					return false, err
-				}
-			}
+		ok, err := f.filterResource(metav1.GroupVersionKind(kind), meta, call, depth)
+		if ok || err != nil {
+			return ok, err
 		}
 	}
 	return false, nil
 }
 
-func filterApp(client resource.Client, resources helmkube.ResourceList, kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
-	if isSameKind(kind, corev1.PodKind) {
-		instance, ok := meta.Labels["app.kubernetes.io/instance"]
-		if ok {
-			daemonSetClient := appsv1.NewDaemonSetsReader(client, resource.NoFilter)
-			daemonSet, err := daemonSetClient.Get(instance)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   appsv1.DaemonSetKind.Group,
-					Version: appsv1.DaemonSetKind.Version,
-					Kind:    appsv1.DaemonSetKind.Kind,
-				}
-				return filterResources(client, resources, groupVersionKind, daemonSet.Object.ObjectMeta)
-			}
-		}
+// filterObject returns whether the object with the given kind and name is part of the release
+func (f *releaseFilter) filterObject(kind schema.GroupVersionKind, namespace, name string, call *filterCall, depth int) (bool, error) {
+	if f.manifest.contains(kind.GroupVersion().String(), kind.Kind, namespace, name) {
+		return true, nil
 	}
-	if isSameKind(kind, appsv1.ReplicaSetKind) {
-		instance, ok := meta.Labels["app.kubernetes.io/instance"]
-		if ok {
-			deploymentClient := appsv1.NewDeploymentsReader(client, resource.NoFilter)
-			deployment, err := deploymentClient.Get(instance)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   appsv1.DeploymentKind.Group,
-					Version: appsv1.DeploymentKind.Version,
-					Kind:    appsv1.DeploymentKind.Kind,
-				}
-				return filterResources(client, resources, groupVersionKind, deployment.Object.ObjectMeta)
-			}
-		}
+	meta, ok, err := f.getObject(namespace, kind, name, "")
+	if !ok || err != nil {
+		return false, err
 	}
-	if isSameKind(kind, corev1.PodKind) {
-		instance, ok := meta.Labels["app.kubernetes.io/instance"]
-		if ok {
-			replicaSetClient := appsv1.NewReplicaSetsReader(client, resource.NoFilter)
-			replicaSet, err := replicaSetClient.Get(instance)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   appsv1.ReplicaSetKind.Group,
-					Version: appsv1.ReplicaSetKind.Version,
-					Kind:    appsv1.ReplicaSetKind.Kind,
-				}
-				return filterResources(client, resources, groupVersionKind, replicaSet.Object.ObjectMeta)
-			}
-		}
+	return f.filterResource(metav1.GroupVersionKind(kind), meta, call, depth)
+}
+
+// filterOwner returns whether the given owner is a release resource or is itself owned by one. The owner is read
+// with the dynamic client, so owners of any kind served by the cluster -- including custom resources -- are followed.
+// If the filter's client is cached, owners are read from the client's informers rather than the API server.
+func (f *releaseFilter) filterOwner(namespace string, owner metav1.OwnerReference, call *filterCall, depth int) (bool, error) {
+	if f.manifest.contains(owner.APIVersion, owner.Kind, namespace, owner.Name) ||
+		f.manifest.contains(owner.APIVersion, owner.Kind, "", owner.Name) {
+		return true, nil
 	}
-	if isSameKind(kind, corev1.PodKind) {
-		instance, ok := meta.Labels["app.kubernetes.io/instance"]
-		if ok {
-			statefulSetClient := appsv1.NewStatefulSetsReader(client, resource.NoFilter)
-			statefulSet, err := statefulSetClient.Get(instance)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   appsv1.StatefulSetKind.Group,
-					Version: appsv1.StatefulSetKind.Version,
-					Kind:    appsv1.StatefulSetKind.Kind,
-				}
-				return filterResources(client, resources, groupVersionKind, statefulSet.Object.ObjectMeta)
-			}
-		}
+	if f.owners.isReleaseOwner(owner.UID) {
+		return true, nil
 	}
-	if isSameKind(kind, appsv1.ReplicaSetKind) {
-		instance, ok := meta.Labels["app.kubernetes.io/instance"]
-		if ok {
-			deploymentClient := appsv1beta1.NewDeploymentsReader(client, resource.NoFilter)
-			deployment, err := deploymentClient.Get(instance)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   appsv1beta1.DeploymentKind.Group,
-					Version: appsv1beta1.DeploymentKind.Version,
-					Kind:    appsv1beta1.DeploymentKind.Kind,
-				}
-				return filterResources(client, resources, groupVersionKind, deployment.Object.ObjectMeta)
-			}
-		}
+	if depth > MaxOwnerDepth || call.visited[owner.UID] {
+		return false, nil
 	}
-	if isSameKind(kind, corev1.PodKind) {
-		instance, ok := meta.Labels["app.kubernetes.io/instance"]
-		if ok {
-			deploymentClient := appsv1beta1.NewDeploymentsReader(client, resource.NoFilter)
-			deployment, err := deploymentClient.Get(instance)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   appsv1beta1.DeploymentKind.Group,
-					Version: appsv1beta1.DeploymentKind.Version,
-					Kind:    appsv1beta1.DeploymentKind.Kind,
-				}
-				return filterResources(client, resources, groupVersionKind, deployment.Object.ObjectMeta)
-			}
-		}
+
+	groupVersion, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		return false, err
 	}
-	if isSameKind(kind, appsv1.ReplicaSetKind) {
-		instance, ok := meta.Labels["app.kubernetes.io/instance"]
-		if ok {
-			statefulSetClient := appsv1beta1.NewStatefulSetsReader(client, resource.NoFilter)
-			statefulSet, err := statefulSetClient.Get(instance)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   appsv1beta1.StatefulSetKind.Group,
-					Version: appsv1beta1.StatefulSetKind.Version,
-					Kind:    appsv1beta1.StatefulSetKind.Kind,
-				}
-				return filterResources(client, resources, groupVersionKind, statefulSet.Object.ObjectMeta)
-			}
-		}
+	meta, ok, err := f.getObject(namespace, groupVersion.WithKind(owner.Kind), owner.Name, owner.UID)
+	if err != nil {
+		return false, err
+	} else if !ok {
+		return false, nil
 	}
-	if isSameKind(kind, corev1.PodKind) {
-		instance, ok := meta.Labels["app.kubernetes.io/instance"]
-		if ok {
-			statefulSetClient := appsv1beta1.NewStatefulSetsReader(client, resource.NoFilter)
-			statefulSet, err := statefulSetClient.Get(instance)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   appsv1beta1.StatefulSetKind.Group,
-					Version: appsv1beta1.StatefulSetKind.Version,
-					Kind:    appsv1beta1.StatefulSetKind.Kind,
-				}
-				return filterResources(client, resources, groupVersionKind, statefulSet.Object.ObjectMeta)
-			}
-		}
+
+	kind := metav1.GroupVersionKind{
+		Group:   groupVersion.Group,
+		Version: groupVersion.Version,
+		Kind:    owner.Kind,
 	}
-	if isSameKind(kind, corev1.EndpointsKind) {
-		instance, ok := meta.Labels["app.kubernetes.io/instance"]
-		if ok {
-			serviceClient := corev1.NewServicesReader(client, resource.NoFilter)
-			service, err := serviceClient.Get(instance)
-			if err != nil && !errors.IsNotFound(err) {
-				return false, err
-			} else if err == nil {
-				groupVersionKind := metav1.GroupVersionKind{
-					Group:   corev1.ServiceKind.Group,
-					Version: corev1.ServiceKind.Version,
-					Kind:    corev1.ServiceKind.Kind,
-				}
-				return filterResources(client, resources, groupVersionKind, service.Object.ObjectMeta)
-			}
-		}
+	ok, err = f.filterResource(kind, meta, call, depth)
+	if err != nil {
+		return false, err
 	}
-	return false, nil
+	if ok {
+		f.owners.addReleaseOwner(owner.UID)
+	}
+	return ok, nil
 }
 
-func isSameKind(groupVersionKind metav1.GroupVersionKind, kind resource.Kind) bool {
-	return groupVersionKind.Group == kind.Group &&
-		groupVersionKind.Version == kind.Version &&
-		groupVersionKind.Kind == kind.Kind
+// getObject returns the metadata of the given object, or false if the object no longer exists. If a UID is given,
+// the object must have the UID.
+func (f *releaseFilter) getObject(namespace string, kind schema.GroupVersionKind, name string, uid types.UID) (metav1.ObjectMeta, bool, error) {
+	client := dynamic.NewClient(resource.ForNamespace(f.client, namespace), resource.NoFilter, kind)
+	if f.prefetch[kind] && uid != "" {
+		err := f.owners.prefetch(kind, namespace, client)
+		if err != nil && !errors.IsNotFound(err) && !apimeta.IsNoMatchError(err) {
+			return metav1.ObjectMeta{}, false, err
+		}
+		if meta, ok := f.owners.getObject(uid); ok {
+			return meta, true, nil
+		}
+	}
+
+	object, err := client.Get(name)
+	if errors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+		return metav1.ObjectMeta{}, false, nil
+	} else if err != nil {
+		return metav1.ObjectMeta{}, false, err
+	} else if uid != "" && object.UID != uid {
+		// The owner was deleted and replaced by another object with the same name
+		return metav1.ObjectMeta{}, false, nil
+	}
+	meta, err := dynamic.GetObjectMeta(object.Object)
+	if err != nil {
+		return metav1.ObjectMeta{}, false, err
+	}
+	return meta, true, nil
 }
@@ -0,0 +1,503 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
+	"github.com/stretchr/testify/assert"
+	helmkube "helm.sh/helm/v3/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/rest"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testClient struct {
+	*resource.Clientsets
+}
+
+func (c *testClient) Namespace() string {
+	return "test"
+}
+
+var podKind = metav1.GroupVersionKind{
+	Version: "v1",
+	Kind:    "Pod",
+}
+
+func newOwnerReference(apiVersion, kind, name string) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Name:       name,
+		UID:        types.UID(kind + "-" + name),
+	}
+}
+
+func newStatefulSet(name string, owner metav1.OwnerReference) map[string]interface{} {
+	return newAppsObject("StatefulSet", name, nil, owner)
+}
+
+func newAppsObject(kind, name string, labels map[string]string, owners ...metav1.OwnerReference) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"namespace":       "test",
+			"name":            name,
+			"uid":             kind + "-" + name,
+			"labels":          labels,
+			"ownerReferences": owners,
+		},
+	}
+}
+
+// newOwnerServer returns a stand-in for the Kubernetes API server which serves discovery for apps and Atomix
+// databases, and the given apps objects
+func newOwnerServer(t *testing.T, objects ...map[string]interface{}) *httptest.Server {
+	return newCountingOwnerServer(t, nil, objects...)
+}
+
+// newCountingOwnerServer returns a stand-in for the Kubernetes API server which counts requests for apps objects
+func newCountingOwnerServer(t *testing.T, requests *int32, objects ...map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(newOwnerHandler(t, requests, objects...))
+}
+
+// newOwnerHandler returns a handler for the owner server
+func newOwnerHandler(t *testing.T, requests *int32, objects ...map[string]interface{}) http.Handler {
+	responses := map[string]interface{}{
+		"/api": &metav1.APIVersions{
+			Versions: []string{"v1"},
+		},
+		"/apis": &metav1.APIGroupList{
+			Groups: []metav1.APIGroup{
+				{
+					Name:             "apps",
+					Versions:         []metav1.GroupVersionForDiscovery{{GroupVersion: "apps/v1", Version: "v1"}},
+					PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "apps/v1", Version: "v1"},
+				},
+				{
+					Name:             "cloud.atomix.io",
+					Versions:         []metav1.GroupVersionForDiscovery{{GroupVersion: "cloud.atomix.io/v1beta2", Version: "v1beta2"}},
+					PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "cloud.atomix.io/v1beta2", Version: "v1beta2"},
+				},
+			},
+		},
+		"/api/v1": &metav1.APIResourceList{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"get", "list"}},
+				{Name: "services", Namespaced: true, Kind: "Service", Verbs: []string{"get", "list"}},
+			},
+		},
+		"/apis/apps/v1": &metav1.APIResourceList{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "statefulsets", Namespaced: true, Kind: "StatefulSet", Verbs: []string{"get", "list"}},
+				{Name: "replicasets", Namespaced: true, Kind: "ReplicaSet", Verbs: []string{"get", "list"}},
+				{Name: "deployments", Namespaced: true, Kind: "Deployment", Verbs: []string{"get", "list"}},
+			},
+		},
+		"/apis/cloud.atomix.io/v1beta2": &metav1.APIResourceList{
+			GroupVersion: "cloud.atomix.io/v1beta2",
+			APIResources: []metav1.APIResource{
+				{Name: "databases", Namespaced: true, Kind: "Database", Verbs: []string{"get", "list"}},
+			},
+		},
+	}
+	lists := make(map[string][]map[string]interface{})
+	for _, object := range objects {
+		metadata := object["metadata"].(map[string]interface{})
+		path := fmt.Sprintf("/apis/apps/v1/namespaces/test/%ss", strings.ToLower(object["kind"].(string)))
+		responses[fmt.Sprintf("%s/%s", path, metadata["name"])] = object
+		lists[path] = append(lists[path], object)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if requests != nil && strings.HasPrefix(req.URL.Path, "/apis/apps/v1/namespaces") {
+			atomic.AddInt32(requests, 1)
+		}
+		response, ok := responses[req.URL.Path]
+		if items, isList := lists[req.URL.Path]; isList {
+			response, ok = newList(items, req.URL.Query().Get("labelSelector")), true
+		}
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	})
+}
+
+// newList returns a list of the given objects with the label in the given key=value selector
+func newList(objects []map[string]interface{}, selector string) map[string]interface{} {
+	items := make([]interface{}, 0, len(objects))
+	for _, object := range objects {
+		labels, _ := object["metadata"].(map[string]interface{})["labels"].(map[string]string)
+		parts := strings.SplitN(selector, "=", 2)
+		if selector == "" || labels[parts[0]] == parts[1] {
+			items = append(items, object)
+		}
+	}
+	return map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "List",
+		"metadata":   map[string]interface{}{},
+		"items":      items,
+	}
+}
+
+func newTestFilter(t *testing.T, server *httptest.Server, opts ...Option) resource.Filter {
+	clientsets, err := resource.NewClientsets(&rest.Config{Host: server.URL, QPS: -1}, nil)
+	assert.NoError(t, err)
+	database := &unstructured.Unstructured{}
+	database.SetAPIVersion("cloud.atomix.io/v1beta2")
+	database.SetKind("Database")
+	database.SetNamespace("test")
+	database.SetName("raft")
+	deployment := &unstructured.Unstructured{}
+	deployment.SetAPIVersion("apps/v1")
+	deployment.SetKind("Deployment")
+	service := &unstructured.Unstructured{}
+	service.SetAPIVersion("v1")
+	service.SetKind("Service")
+	resources := helmkube.ResourceList{
+		&cliresource.Info{
+			Namespace: "test",
+			Name:      "raft",
+			Object:    database,
+		},
+		&cliresource.Info{
+			Namespace: "test",
+			Name:      "web",
+			Object:    deployment,
+		},
+		&cliresource.Info{
+			Namespace: "test",
+			Name:      "web",
+			Object:    service,
+		},
+	}
+	return Resources(&testClient{clientsets}, resources, opts...)
+}
+
+func newPod(owner metav1.OwnerReference) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace:       "test",
+		Name:            "pod",
+		UID:             "pod",
+		OwnerReferences: []metav1.OwnerReference{owner},
+	}
+}
+
+func TestFilterCustomResourceOwner(t *testing.T) {
+	server := newOwnerServer(t,
+		newStatefulSet("raft", newOwnerReference("cloud.atomix.io/v1beta2", "Database", "raft")),
+		newStatefulSet("other", newOwnerReference("cloud.atomix.io/v1beta2", "Database", "other")),
+		newStatefulSet("stale", newOwnerReference("cloud.atomix.io/v1beta2", "Database", "raft")))
+	defer server.Close()
+	filter := newTestFilter(t, server)
+
+	ok, err := filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "raft")))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "other")))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "missing")))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	owner := newOwnerReference("apps/v1", "StatefulSet", "stale")
+	owner.UID = "replaced"
+	ok, err = filter(podKind, newPod(owner))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = filter(podKind, newPod(newOwnerReference("cloud.atomix.io/v1beta2", "Partition", "raft")))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestFilterOwnerCycle(t *testing.T) {
+	server := newOwnerServer(t,
+		newStatefulSet("a", newOwnerReference("apps/v1", "StatefulSet", "b")),
+		newStatefulSet("b", newOwnerReference("apps/v1", "StatefulSet", "a")))
+	defer server.Close()
+	filter := newTestFilter(t, server)
+
+	ok, err := filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "a")))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestFilterOwnerDepth(t *testing.T) {
+	statefulSets := make([]map[string]interface{}, 0, MaxOwnerDepth*2)
+	for i := 0; i < MaxOwnerDepth*2; i++ {
+		statefulSets = append(statefulSets, newStatefulSet(fmt.Sprintf("chain-%d", i), newOwnerReference("apps/v1", "StatefulSet", fmt.Sprintf("chain-%d", i+1))))
+	}
+	statefulSets = append(statefulSets, newStatefulSet(fmt.Sprintf("chain-%d", MaxOwnerDepth*2), newOwnerReference("cloud.atomix.io/v1beta2", "Database", "raft")))
+	server := newOwnerServer(t, statefulSets...)
+	defer server.Close()
+	filter := newTestFilter(t, server)
+
+	ok, err := filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", fmt.Sprintf("chain-%d", MaxOwnerDepth+1))))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "chain-0")))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestFilterOwnerCache(t *testing.T) {
+	var requests int32
+	server := newCountingOwnerServer(t, &requests,
+		newStatefulSet("raft", newOwnerReference("cloud.atomix.io/v1beta2", "Database", "raft")),
+		newStatefulSet("other", newOwnerReference("cloud.atomix.io/v1beta2", "Database", "other")))
+	defer server.Close()
+	owners := NewOwnerCache()
+	now := time.Now()
+	owners.now = func() time.Time {
+		return now
+	}
+	filter := newTestFilter(t, server, WithOwnerCache(owners))
+
+	for i := 0; i < 50; i++ {
+		ok, err := filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "raft")))
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// The cache can be shared by another filter for the same release
+	filter = newTestFilter(t, server, WithOwnerCache(owners))
+	ok, err := filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "raft")))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// Owners that are not part of the release are read again, since they may not yet be visible
+	for i := 0; i < 2; i++ {
+		ok, err = filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "other")))
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	// Cached owners expire
+	now = now.Add(OwnerCacheTTL)
+	ok, err = filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "raft")))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&requests))
+}
+
+// TestFilterMissingOwner verifies an owner that is not yet visible is found once it appears
+func TestFilterMissingOwner(t *testing.T) {
+	handler := newOwnerHandler(t, nil)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		handler.ServeHTTP(w, req)
+	}))
+	defer server.Close()
+	filter := newTestFilter(t, server)
+
+	ok, err := filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "raft")))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	handler = newOwnerHandler(t, nil, newStatefulSet("raft", newOwnerReference("cloud.atomix.io/v1beta2", "Database", "raft")))
+	ok, err = filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "raft")))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestFilterPrefetch(t *testing.T) {
+	var requests int32
+	statefulSets := make([]map[string]interface{}, 0, 10)
+	for i := 0; i < 10; i++ {
+		statefulSets = append(statefulSets, newStatefulSet(fmt.Sprintf("raft-%d", i), newOwnerReference("cloud.atomix.io/v1beta2", "Database", "raft")))
+	}
+	server := newCountingOwnerServer(t, &requests, statefulSets...)
+	defer server.Close()
+	filter := newTestFilter(t, server, WithPrefetch(schema.GroupVersionKind{
+		Group:   "apps",
+		Version: "v1",
+		Kind:    "StatefulSet",
+	}))
+
+	for i := 0; i < 10; i++ {
+		ok, err := filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", fmt.Sprintf("raft-%d", i))))
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// Owners missing from the prefetched list are read individually
+	ok, err := filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "missing")))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestFilterRelated(t *testing.T) {
+	hash := map[string]string{"pod-template-hash": "5d4f7b"}
+	server := newOwnerServer(t,
+		newAppsObject("ReplicaSet", "web-5d4f7b", hash, newOwnerReference("apps/v1", "Deployment", "web")),
+		newAppsObject("ReplicaSet", "api-6c8d9f", map[string]string{"pod-template-hash": "6c8d9f"}),
+		newAppsObject("StatefulSet", "web", nil))
+	defer server.Close()
+	filter := newTestFilter(t, server)
+
+	// Pods orphaned by their replica set are matched by the pod template hash
+	ok, err := filter(podKind, metav1.ObjectMeta{Namespace: "test", Name: "web-5d4f7b-x7k2p", Labels: hash})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = filter(podKind, metav1.ObjectMeta{Namespace: "test", Name: "api-6c8d9f-x7k2p", Labels: map[string]string{"pod-template-hash": "6c8d9f"}})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Replica sets are matched by their owner rather than by name
+	replicaSetKind := metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"}
+	ok, err = filter(replicaSetKind, metav1.ObjectMeta{
+		Namespace:       "test",
+		Name:            "web-5d4f7b",
+		Labels:          hash,
+		OwnerReferences: []metav1.OwnerReference{newOwnerReference("apps/v1", "Deployment", "web")},
+	})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = filter(replicaSetKind, metav1.ObjectMeta{Namespace: "test", Name: "web-5d4f7b", Labels: hash})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Pods are matched by their controller rather than the instance label
+	controller := newOwnerReference("apps/v1", "StatefulSet", "web")
+	isController := true
+	controller.Controller = &isController
+	ok, err = filter(podKind, metav1.ObjectMeta{
+		Namespace:       "test",
+		Name:            "web-0",
+		Labels:          map[string]string{"app.kubernetes.io/instance": "web", "pod-template-hash": "5d4f7b"},
+		OwnerReferences: []metav1.OwnerReference{controller},
+	})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = filter(metav1.GroupVersionKind{Version: "v1", Kind: "Endpoints"}, metav1.ObjectMeta{Namespace: "test", Name: "web"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = filter(metav1.GroupVersionKind{Group: "discovery.k8s.io", Version: "v1beta1", Kind: "EndpointSlice"}, metav1.ObjectMeta{
+		Namespace: "test",
+		Name:      "web-abcde",
+		Labels:    map[string]string{"kubernetes.io/service-name": "web"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestFilterVolumeClaim(t *testing.T) {
+	statefulSet := newStatefulSet("raft-db", newOwnerReference("cloud.atomix.io/v1beta2", "Database", "raft"))
+	statefulSet["spec"] = map[string]interface{}{
+		"volumeClaimTemplates": []interface{}{
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "data"}},
+		},
+	}
+	var requests int32
+	server := newCountingOwnerServer(t, &requests, statefulSet)
+	defer server.Close()
+	filter := newTestFilter(t, server)
+
+	claimKind := metav1.GroupVersionKind{Version: "v1", Kind: "PersistentVolumeClaim"}
+	ok, err := filter(claimKind, metav1.ObjectMeta{Namespace: "test", Name: "data-raft-db-0"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	// The stateful sets are listed once rather than read by each possible name
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	ok, err = filter(claimKind, metav1.ObjectMeta{Namespace: "test", Name: "logs-raft-db-0"})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = filter(claimKind, metav1.ObjectMeta{Namespace: "test", Name: "data-raft-db"})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestGetRelated(t *testing.T) {
+	related, ok := GetRelated(metav1.GroupVersionKind{Version: "v1", Kind: "Endpoints"}, metav1.ObjectMeta{Name: "onos"})
+	assert.True(t, ok)
+	assert.Equal(t, Related{Kind: serviceKind, Name: "onos"}, related)
+
+	related, ok = GetRelated(podKind, metav1.ObjectMeta{Name: "onos-6c8d9f-8j2lq", Labels: map[string]string{PodTemplateHashLabel: "6c8d9f"}})
+	assert.True(t, ok)
+	assert.Equal(t, replicaSetKind, related.Kind)
+	assert.Equal(t, "", related.Name)
+	assert.Equal(t, "pod-template-hash=6c8d9f", related.Labels.String())
+
+	_, ok = GetRelated(podKind, metav1.ObjectMeta{Name: "onos-0"})
+	assert.False(t, ok)
+
+	statefulSet := &unstructured.Unstructured{Object: newStatefulSet("raft-db", newOwnerReference("apps/v1", "Deployment", "raft"))}
+	assert.NoError(t, unstructured.SetNestedSlice(statefulSet.Object, []interface{}{
+		map[string]interface{}{"metadata": map[string]interface{}{"name": "data"}},
+	}, "spec", "volumeClaimTemplates"))
+	assert.True(t, IsVolumeClaimOf("data-raft-db-0", statefulSet))
+	assert.False(t, IsVolumeClaimOf("logs-raft-db-0", statefulSet))
+	assert.False(t, IsVolumeClaimOf("data-raft-db", statefulSet))
+	assert.False(t, IsVolumeClaimOf("data-other-raft-db-0", statefulSet))
+}
+
+func TestFilterHelmLabels(t *testing.T) {
+	statefulSet := newStatefulSet("onos", newOwnerReference("apps/v1", "Deployment", "missing"))
+	metadata := statefulSet["metadata"].(map[string]interface{})
+	metadata["labels"] = map[string]string{"app.kubernetes.io/managed-by": "Helm"}
+	metadata["annotations"] = map[string]string{
+		"meta.helm.sh/release-name":      "onos",
+		"meta.helm.sh/release-namespace": "test",
+	}
+	server := newOwnerServer(t, statefulSet)
+	defer server.Close()
+
+	filter := newTestFilter(t, server, WithHelmLabels("onos", "test"))
+	ok, err := filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "onos")))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	filter = newTestFilter(t, server, WithHelmLabels("other", "test"))
+	ok, err = filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "onos")))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	filter = newTestFilter(t, server)
+	ok, err = filter(podKind, newPod(newOwnerReference("apps/v1", "StatefulSet", "onos")))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
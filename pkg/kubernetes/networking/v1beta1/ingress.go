@@ -6,12 +6,13 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
 var IngressKind = resource.Kind{
-	Group:   "networking",
+	Group:   "networking.k8s.io",
 	Version: "v1beta1",
 	Kind:    "Ingress",
 	Scoped:  true,
@@ -23,6 +24,7 @@ var IngressResource = resource.Type{
 }
 
 func NewIngress(ingress *networkingv1beta1.Ingress, client resource.Client) *Ingress {
+	client = resource.ForNamespace(client, ingress.Namespace)
 	return &Ingress{
 		Resource: resource.NewResource(ingress.ObjectMeta, IngressKind, client),
 		Object:   ingress,
@@ -35,12 +37,11 @@ type Ingress struct {
 }
 
 func (r *Ingress) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.NetworkingV1beta1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, IngressKind.Scoped).
 		Resource(IngressResource.Name).
@@ -50,3 +51,94 @@ func (r *Ingress) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *Ingress) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &networkingv1beta1.Ingress{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, IngressKind.Scoped).
+		Resource(IngressResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Ingress) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &networkingv1beta1.Ingress{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, IngressKind.Scoped).
+		Resource(IngressResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Ingress) Update(mutate func(*networkingv1beta1.Ingress) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		ingress := r.Object.DeepCopy()
+		if err := mutate(ingress); err != nil {
+			return err
+		}
+		result := &networkingv1beta1.Ingress{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, IngressKind.Scoped).
+			Resource(IngressResource.Name).
+			Name(r.Name).
+			Body(ingress).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *Ingress) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *Ingress) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *Ingress) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *Ingress) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
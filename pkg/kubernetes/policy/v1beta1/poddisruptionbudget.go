@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var PodDisruptionBudgetResource = resource.Type{
 }
 
 func NewPodDisruptionBudget(podDisruptionBudget *policyv1beta1.PodDisruptionBudget, client resource.Client) *PodDisruptionBudget {
+	client = resource.ForNamespace(client, podDisruptionBudget.Namespace)
 	return &PodDisruptionBudget{
 		Resource: resource.NewResource(podDisruptionBudget.ObjectMeta, PodDisruptionBudgetKind, client),
 		Object:   podDisruptionBudget,
@@ -35,12 +37,11 @@ type PodDisruptionBudget struct {
 }
 
 func (r *PodDisruptionBudget) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.PolicyV1beta1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, PodDisruptionBudgetKind.Scoped).
 		Resource(PodDisruptionBudgetResource.Name).
@@ -50,3 +51,94 @@ func (r *PodDisruptionBudget) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *PodDisruptionBudget) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &policyv1beta1.PodDisruptionBudget{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, PodDisruptionBudgetKind.Scoped).
+		Resource(PodDisruptionBudgetResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *PodDisruptionBudget) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &policyv1beta1.PodDisruptionBudget{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, PodDisruptionBudgetKind.Scoped).
+		Resource(PodDisruptionBudgetResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *PodDisruptionBudget) Update(mutate func(*policyv1beta1.PodDisruptionBudget) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		podDisruptionBudget := r.Object.DeepCopy()
+		if err := mutate(podDisruptionBudget); err != nil {
+			return err
+		}
+		result := &policyv1beta1.PodDisruptionBudget{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, PodDisruptionBudgetKind.Scoped).
+			Resource(PodDisruptionBudgetResource.Name).
+			Name(r.Name).
+			Body(podDisruptionBudget).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *PodDisruptionBudget) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *PodDisruptionBudget) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *PodDisruptionBudget) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *PodDisruptionBudget) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
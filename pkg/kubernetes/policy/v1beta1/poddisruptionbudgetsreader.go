@@ -3,18 +3,31 @@
 package v1beta1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type PodDisruptionBudgetsReader interface {
 	Get(name string) (*PodDisruptionBudget, error)
-	List() ([]*PodDisruptionBudget, error)
+	List(opts ...resource.ListOption) ([]*PodDisruptionBudget, error)
+	ListPages(handler func([]*PodDisruptionBudget) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan PodDisruptionBudgetEvent, error)
+	WaitFor(ctx context.Context, predicate func(PodDisruptionBudgetEvent) (bool, error)) (PodDisruptionBudgetEvent, error)
+}
+
+type PodDisruptionBudgetEvent struct {
+	Type                resource.EventType
+	PodDisruptionBudget *PodDisruptionBudget
 }
 
 func NewPodDisruptionBudgetsReader(client resource.Client, filter resource.Filter) PodDisruptionBudgetsReader {
@@ -30,24 +43,13 @@ type podDisruptionBudgetsReader struct {
 }
 
 func (c *podDisruptionBudgetsReader) Get(name string) (*PodDisruptionBudget, error) {
-	podDisruptionBudget := &policyv1beta1.PodDisruptionBudget{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.PolicyV1beta1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), PodDisruptionBudgetKind.Scoped).
-		Resource(PodDisruptionBudgetResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(podDisruptionBudget)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, PodDisruptionBudgetKind.Scoped) {
+		podDisruptionBudget, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   PodDisruptionBudgetKind.Group,
 			Version: PodDisruptionBudgetKind.Version,
@@ -55,37 +57,123 @@ func (c *podDisruptionBudgetsReader) Get(name string) (*PodDisruptionBudget, err
 		}, podDisruptionBudget.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewPodDisruptionBudget(podDisruptionBudget, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    PodDisruptionBudgetKind.Group,
+		Resource: PodDisruptionBudgetResource.Name,
+	}, name)
+}
+
+func (c *podDisruptionBudgetsReader) get(namespace, name string) (*policyv1beta1.PodDisruptionBudget, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, PodDisruptionBudgetKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    PodDisruptionBudgetKind.Group,
 				Resource: PodDisruptionBudgetResource.Name,
 			}, name)
 		}
+		return object.(*policyv1beta1.PodDisruptionBudget).DeepCopy(), nil
 	}
-	return NewPodDisruptionBudget(podDisruptionBudget, c.Client), nil
-}
 
-func (c *podDisruptionBudgetsReader) List() ([]*PodDisruptionBudget, error) {
-	list := &policyv1beta1.PodDisruptionBudgetList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &policyv1beta1.PodDisruptionBudget{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.PolicyV1beta1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), PodDisruptionBudgetKind.Scoped).
+		NamespaceIfScoped(namespace, PodDisruptionBudgetKind.Scoped).
 		Resource(PodDisruptionBudgetResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*PodDisruptionBudget, 0, len(list.Items))
-	for _, podDisruptionBudget := range list.Items {
+func (c *podDisruptionBudgetsReader) List(opts ...resource.ListOption) ([]*PodDisruptionBudget, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, PodDisruptionBudgetKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*PodDisruptionBudget, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *podDisruptionBudgetsReader) ListPages(handler func([]*PodDisruptionBudget) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, PodDisruptionBudgetKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *podDisruptionBudgetsReader) list(namespace string, options metav1.ListOptions) ([]*PodDisruptionBudget, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *podDisruptionBudgetsReader) filterItems(items []policyv1beta1.PodDisruptionBudget) ([]*PodDisruptionBudget, error) {
+	results := make([]*PodDisruptionBudget, 0, len(items))
+	for _, podDisruptionBudget := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   PodDisruptionBudgetKind.Group,
 			Version: PodDisruptionBudgetKind.Version,
@@ -100,3 +188,145 @@ func (c *podDisruptionBudgetsReader) List() ([]*PodDisruptionBudget, error) {
 	}
 	return results, nil
 }
+
+func (c *podDisruptionBudgetsReader) listItems(namespace string, options metav1.ListOptions) ([]policyv1beta1.PodDisruptionBudget, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]policyv1beta1.PodDisruptionBudget, 0, len(objects))
+			for _, object := range objects {
+				podDisruptionBudget := object.(*policyv1beta1.PodDisruptionBudget)
+				if selector.Matches(labels.Set(podDisruptionBudget.Labels)) {
+					items = append(items, *podDisruptionBudget.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &policyv1beta1.PodDisruptionBudgetList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, PodDisruptionBudgetKind.Scoped).
+		Resource(PodDisruptionBudgetResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *podDisruptionBudgetsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(PodDisruptionBudgetKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &policyv1beta1.PodDisruptionBudgetList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, PodDisruptionBudgetKind.Scoped).
+					Resource(PodDisruptionBudgetResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, PodDisruptionBudgetKind.Scoped).
+					Resource(PodDisruptionBudgetResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &policyv1beta1.PodDisruptionBudget{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *podDisruptionBudgetsReader) Watch(ctx context.Context) (<-chan PodDisruptionBudgetEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, PodDisruptionBudgetKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, PodDisruptionBudgetKind.Scoped).
+			Resource(PodDisruptionBudgetResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan PodDisruptionBudgetEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			podDisruptionBudget, ok := watchEvent.Object.(*policyv1beta1.PodDisruptionBudget)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   PodDisruptionBudgetKind.Group,
+				Version: PodDisruptionBudgetKind.Version,
+				Kind:    PodDisruptionBudgetKind.Kind,
+			}, podDisruptionBudget.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- PodDisruptionBudgetEvent{Type: watchEvent.Type, PodDisruptionBudget: NewPodDisruptionBudget(podDisruptionBudget, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *podDisruptionBudgetsReader) WaitFor(ctx context.Context, predicate func(PodDisruptionBudgetEvent) (bool, error)) (PodDisruptionBudgetEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return PodDisruptionBudgetEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return PodDisruptionBudgetEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return PodDisruptionBudgetEvent{}, ctx.Err()
+}
@@ -3,18 +3,31 @@
 package v1beta1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type PodSecurityPoliciesReader interface {
 	Get(name string) (*PodSecurityPolicy, error)
-	List() ([]*PodSecurityPolicy, error)
+	List(opts ...resource.ListOption) ([]*PodSecurityPolicy, error)
+	ListPages(handler func([]*PodSecurityPolicy) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan PodSecurityPolicyEvent, error)
+	WaitFor(ctx context.Context, predicate func(PodSecurityPolicyEvent) (bool, error)) (PodSecurityPolicyEvent, error)
+}
+
+type PodSecurityPolicyEvent struct {
+	Type              resource.EventType
+	PodSecurityPolicy *PodSecurityPolicy
 }
 
 func NewPodSecurityPoliciesReader(client resource.Client, filter resource.Filter) PodSecurityPoliciesReader {
@@ -30,24 +43,13 @@ type podSecurityPoliciesReader struct {
 }
 
 func (c *podSecurityPoliciesReader) Get(name string) (*PodSecurityPolicy, error) {
-	podSecurityPolicy := &policyv1beta1.PodSecurityPolicy{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.PolicyV1beta1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), PodSecurityPolicyKind.Scoped).
-		Resource(PodSecurityPolicyResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(podSecurityPolicy)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, PodSecurityPolicyKind.Scoped) {
+		podSecurityPolicy, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   PodSecurityPolicyKind.Group,
 			Version: PodSecurityPolicyKind.Version,
@@ -55,37 +57,123 @@ func (c *podSecurityPoliciesReader) Get(name string) (*PodSecurityPolicy, error)
 		}, podSecurityPolicy.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewPodSecurityPolicy(podSecurityPolicy, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    PodSecurityPolicyKind.Group,
+		Resource: PodSecurityPolicyResource.Name,
+	}, name)
+}
+
+func (c *podSecurityPoliciesReader) get(namespace, name string) (*policyv1beta1.PodSecurityPolicy, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, PodSecurityPolicyKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    PodSecurityPolicyKind.Group,
 				Resource: PodSecurityPolicyResource.Name,
 			}, name)
 		}
+		return object.(*policyv1beta1.PodSecurityPolicy).DeepCopy(), nil
 	}
-	return NewPodSecurityPolicy(podSecurityPolicy, c.Client), nil
-}
 
-func (c *podSecurityPoliciesReader) List() ([]*PodSecurityPolicy, error) {
-	list := &policyv1beta1.PodSecurityPolicyList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &policyv1beta1.PodSecurityPolicy{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.PolicyV1beta1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), PodSecurityPolicyKind.Scoped).
+		NamespaceIfScoped(namespace, PodSecurityPolicyKind.Scoped).
 		Resource(PodSecurityPolicyResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*PodSecurityPolicy, 0, len(list.Items))
-	for _, podSecurityPolicy := range list.Items {
+func (c *podSecurityPoliciesReader) List(opts ...resource.ListOption) ([]*PodSecurityPolicy, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, PodSecurityPolicyKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*PodSecurityPolicy, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *podSecurityPoliciesReader) ListPages(handler func([]*PodSecurityPolicy) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, PodSecurityPolicyKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *podSecurityPoliciesReader) list(namespace string, options metav1.ListOptions) ([]*PodSecurityPolicy, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *podSecurityPoliciesReader) filterItems(items []policyv1beta1.PodSecurityPolicy) ([]*PodSecurityPolicy, error) {
+	results := make([]*PodSecurityPolicy, 0, len(items))
+	for _, podSecurityPolicy := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   PodSecurityPolicyKind.Group,
 			Version: PodSecurityPolicyKind.Version,
@@ -100,3 +188,145 @@ func (c *podSecurityPoliciesReader) List() ([]*PodSecurityPolicy, error) {
 	}
 	return results, nil
 }
+
+func (c *podSecurityPoliciesReader) listItems(namespace string, options metav1.ListOptions) ([]policyv1beta1.PodSecurityPolicy, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]policyv1beta1.PodSecurityPolicy, 0, len(objects))
+			for _, object := range objects {
+				podSecurityPolicy := object.(*policyv1beta1.PodSecurityPolicy)
+				if selector.Matches(labels.Set(podSecurityPolicy.Labels)) {
+					items = append(items, *podSecurityPolicy.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &policyv1beta1.PodSecurityPolicyList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, PodSecurityPolicyKind.Scoped).
+		Resource(PodSecurityPolicyResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *podSecurityPoliciesReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(PodSecurityPolicyKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &policyv1beta1.PodSecurityPolicyList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, PodSecurityPolicyKind.Scoped).
+					Resource(PodSecurityPolicyResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, PodSecurityPolicyKind.Scoped).
+					Resource(PodSecurityPolicyResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &policyv1beta1.PodSecurityPolicy{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *podSecurityPoliciesReader) Watch(ctx context.Context) (<-chan PodSecurityPolicyEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, PodSecurityPolicyKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, PodSecurityPolicyKind.Scoped).
+			Resource(PodSecurityPolicyResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan PodSecurityPolicyEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			podSecurityPolicy, ok := watchEvent.Object.(*policyv1beta1.PodSecurityPolicy)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   PodSecurityPolicyKind.Group,
+				Version: PodSecurityPolicyKind.Version,
+				Kind:    PodSecurityPolicyKind.Kind,
+			}, podSecurityPolicy.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- PodSecurityPolicyEvent{Type: watchEvent.Type, PodSecurityPolicy: NewPodSecurityPolicy(podSecurityPolicy, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *podSecurityPoliciesReader) WaitFor(ctx context.Context, predicate func(PodSecurityPolicyEvent) (bool, error)) (PodSecurityPolicyEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return PodSecurityPolicyEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return PodSecurityPolicyEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return PodSecurityPolicyEvent{}, ctx.Err()
+}
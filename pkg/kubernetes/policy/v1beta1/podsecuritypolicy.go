@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -14,7 +15,7 @@ var PodSecurityPolicyKind = resource.Kind{
 	Group:   "policy",
 	Version: "v1beta1",
 	Kind:    "PodSecurityPolicy",
-	Scoped:  true,
+	Scoped:  false,
 }
 
 var PodSecurityPolicyResource = resource.Type{
@@ -23,6 +24,7 @@ var PodSecurityPolicyResource = resource.Type{
 }
 
 func NewPodSecurityPolicy(podSecurityPolicy *policyv1beta1.PodSecurityPolicy, client resource.Client) *PodSecurityPolicy {
+	client = resource.ForNamespace(client, podSecurityPolicy.Namespace)
 	return &PodSecurityPolicy{
 		Resource: resource.NewResource(podSecurityPolicy.ObjectMeta, PodSecurityPolicyKind, client),
 		Object:   podSecurityPolicy,
@@ -35,12 +37,11 @@ type PodSecurityPolicy struct {
 }
 
 func (r *PodSecurityPolicy) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.PolicyV1beta1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, PodSecurityPolicyKind.Scoped).
 		Resource(PodSecurityPolicyResource.Name).
@@ -50,3 +51,94 @@ func (r *PodSecurityPolicy) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *PodSecurityPolicy) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &policyv1beta1.PodSecurityPolicy{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, PodSecurityPolicyKind.Scoped).
+		Resource(PodSecurityPolicyResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *PodSecurityPolicy) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &policyv1beta1.PodSecurityPolicy{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, PodSecurityPolicyKind.Scoped).
+		Resource(PodSecurityPolicyResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *PodSecurityPolicy) Update(mutate func(*policyv1beta1.PodSecurityPolicy) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		podSecurityPolicy := r.Object.DeepCopy()
+		if err := mutate(podSecurityPolicy); err != nil {
+			return err
+		}
+		result := &policyv1beta1.PodSecurityPolicy{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, PodSecurityPolicyKind.Scoped).
+			Resource(PodSecurityPolicyResource.Name).
+			Name(r.Name).
+			Body(podSecurityPolicy).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *PodSecurityPolicy) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *PodSecurityPolicy) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *PodSecurityPolicy) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *PodSecurityPolicy) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var ClusterRoleResource = resource.Type{
 }
 
 func NewClusterRole(clusterRole *rbacv1.ClusterRole, client resource.Client) *ClusterRole {
+	client = resource.ForNamespace(client, clusterRole.Namespace)
 	return &ClusterRole{
 		Resource: resource.NewResource(clusterRole.ObjectMeta, ClusterRoleKind, client),
 		Object:   clusterRole,
@@ -35,12 +37,11 @@ type ClusterRole struct {
 }
 
 func (r *ClusterRole) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.RbacV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, ClusterRoleKind.Scoped).
 		Resource(ClusterRoleResource.Name).
@@ -50,3 +51,94 @@ func (r *ClusterRole) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *ClusterRole) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &rbacv1.ClusterRole{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, ClusterRoleKind.Scoped).
+		Resource(ClusterRoleResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *ClusterRole) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &rbacv1.ClusterRole{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, ClusterRoleKind.Scoped).
+		Resource(ClusterRoleResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *ClusterRole) Update(mutate func(*rbacv1.ClusterRole) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		clusterRole := r.Object.DeepCopy()
+		if err := mutate(clusterRole); err != nil {
+			return err
+		}
+		result := &rbacv1.ClusterRole{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, ClusterRoleKind.Scoped).
+			Resource(ClusterRoleResource.Name).
+			Name(r.Name).
+			Body(clusterRole).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *ClusterRole) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *ClusterRole) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *ClusterRole) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *ClusterRole) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
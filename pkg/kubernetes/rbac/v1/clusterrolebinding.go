@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var ClusterRoleBindingResource = resource.Type{
 }
 
 func NewClusterRoleBinding(clusterRoleBinding *rbacv1.ClusterRoleBinding, client resource.Client) *ClusterRoleBinding {
+	client = resource.ForNamespace(client, clusterRoleBinding.Namespace)
 	return &ClusterRoleBinding{
 		Resource: resource.NewResource(clusterRoleBinding.ObjectMeta, ClusterRoleBindingKind, client),
 		Object:   clusterRoleBinding,
@@ -35,12 +37,11 @@ type ClusterRoleBinding struct {
 }
 
 func (r *ClusterRoleBinding) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.RbacV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, ClusterRoleBindingKind.Scoped).
 		Resource(ClusterRoleBindingResource.Name).
@@ -50,3 +51,94 @@ func (r *ClusterRoleBinding) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *ClusterRoleBinding) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &rbacv1.ClusterRoleBinding{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, ClusterRoleBindingKind.Scoped).
+		Resource(ClusterRoleBindingResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *ClusterRoleBinding) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &rbacv1.ClusterRoleBinding{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, ClusterRoleBindingKind.Scoped).
+		Resource(ClusterRoleBindingResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *ClusterRoleBinding) Update(mutate func(*rbacv1.ClusterRoleBinding) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		clusterRoleBinding := r.Object.DeepCopy()
+		if err := mutate(clusterRoleBinding); err != nil {
+			return err
+		}
+		result := &rbacv1.ClusterRoleBinding{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, ClusterRoleBindingKind.Scoped).
+			Resource(ClusterRoleBindingResource.Name).
+			Name(r.Name).
+			Body(clusterRoleBinding).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *ClusterRoleBinding) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *ClusterRoleBinding) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *ClusterRoleBinding) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *ClusterRoleBinding) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
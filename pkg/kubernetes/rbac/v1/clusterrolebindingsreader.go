@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type ClusterRoleBindingsReader interface {
 	Get(name string) (*ClusterRoleBinding, error)
-	List() ([]*ClusterRoleBinding, error)
+	List(opts ...resource.ListOption) ([]*ClusterRoleBinding, error)
+	ListPages(handler func([]*ClusterRoleBinding) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan ClusterRoleBindingEvent, error)
+	WaitFor(ctx context.Context, predicate func(ClusterRoleBindingEvent) (bool, error)) (ClusterRoleBindingEvent, error)
+}
+
+type ClusterRoleBindingEvent struct {
+	Type               resource.EventType
+	ClusterRoleBinding *ClusterRoleBinding
 }
 
 func NewClusterRoleBindingsReader(client resource.Client, filter resource.Filter) ClusterRoleBindingsReader {
@@ -30,24 +43,13 @@ type clusterRoleBindingsReader struct {
 }
 
 func (c *clusterRoleBindingsReader) Get(name string) (*ClusterRoleBinding, error) {
-	clusterRoleBinding := &rbacv1.ClusterRoleBinding{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.RbacV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), ClusterRoleBindingKind.Scoped).
-		Resource(ClusterRoleBindingResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(clusterRoleBinding)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, ClusterRoleBindingKind.Scoped) {
+		clusterRoleBinding, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   ClusterRoleBindingKind.Group,
 			Version: ClusterRoleBindingKind.Version,
@@ -55,37 +57,123 @@ func (c *clusterRoleBindingsReader) Get(name string) (*ClusterRoleBinding, error
 		}, clusterRoleBinding.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewClusterRoleBinding(clusterRoleBinding, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    ClusterRoleBindingKind.Group,
+		Resource: ClusterRoleBindingResource.Name,
+	}, name)
+}
+
+func (c *clusterRoleBindingsReader) get(namespace, name string) (*rbacv1.ClusterRoleBinding, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, ClusterRoleBindingKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    ClusterRoleBindingKind.Group,
 				Resource: ClusterRoleBindingResource.Name,
 			}, name)
 		}
+		return object.(*rbacv1.ClusterRoleBinding).DeepCopy(), nil
 	}
-	return NewClusterRoleBinding(clusterRoleBinding, c.Client), nil
-}
 
-func (c *clusterRoleBindingsReader) List() ([]*ClusterRoleBinding, error) {
-	list := &rbacv1.ClusterRoleBindingList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &rbacv1.ClusterRoleBinding{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.RbacV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), ClusterRoleBindingKind.Scoped).
+		NamespaceIfScoped(namespace, ClusterRoleBindingKind.Scoped).
 		Resource(ClusterRoleBindingResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*ClusterRoleBinding, 0, len(list.Items))
-	for _, clusterRoleBinding := range list.Items {
+func (c *clusterRoleBindingsReader) List(opts ...resource.ListOption) ([]*ClusterRoleBinding, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, ClusterRoleBindingKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*ClusterRoleBinding, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *clusterRoleBindingsReader) ListPages(handler func([]*ClusterRoleBinding) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, ClusterRoleBindingKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *clusterRoleBindingsReader) list(namespace string, options metav1.ListOptions) ([]*ClusterRoleBinding, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *clusterRoleBindingsReader) filterItems(items []rbacv1.ClusterRoleBinding) ([]*ClusterRoleBinding, error) {
+	results := make([]*ClusterRoleBinding, 0, len(items))
+	for _, clusterRoleBinding := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   ClusterRoleBindingKind.Group,
 			Version: ClusterRoleBindingKind.Version,
@@ -100,3 +188,145 @@ func (c *clusterRoleBindingsReader) List() ([]*ClusterRoleBinding, error) {
 	}
 	return results, nil
 }
+
+func (c *clusterRoleBindingsReader) listItems(namespace string, options metav1.ListOptions) ([]rbacv1.ClusterRoleBinding, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]rbacv1.ClusterRoleBinding, 0, len(objects))
+			for _, object := range objects {
+				clusterRoleBinding := object.(*rbacv1.ClusterRoleBinding)
+				if selector.Matches(labels.Set(clusterRoleBinding.Labels)) {
+					items = append(items, *clusterRoleBinding.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &rbacv1.ClusterRoleBindingList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, ClusterRoleBindingKind.Scoped).
+		Resource(ClusterRoleBindingResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *clusterRoleBindingsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(ClusterRoleBindingKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &rbacv1.ClusterRoleBindingList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, ClusterRoleBindingKind.Scoped).
+					Resource(ClusterRoleBindingResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, ClusterRoleBindingKind.Scoped).
+					Resource(ClusterRoleBindingResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &rbacv1.ClusterRoleBinding{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *clusterRoleBindingsReader) Watch(ctx context.Context) (<-chan ClusterRoleBindingEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, ClusterRoleBindingKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, ClusterRoleBindingKind.Scoped).
+			Resource(ClusterRoleBindingResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ClusterRoleBindingEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			clusterRoleBinding, ok := watchEvent.Object.(*rbacv1.ClusterRoleBinding)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   ClusterRoleBindingKind.Group,
+				Version: ClusterRoleBindingKind.Version,
+				Kind:    ClusterRoleBindingKind.Kind,
+			}, clusterRoleBinding.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- ClusterRoleBindingEvent{Type: watchEvent.Type, ClusterRoleBinding: NewClusterRoleBinding(clusterRoleBinding, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *clusterRoleBindingsReader) WaitFor(ctx context.Context, predicate func(ClusterRoleBindingEvent) (bool, error)) (ClusterRoleBindingEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return ClusterRoleBindingEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return ClusterRoleBindingEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return ClusterRoleBindingEvent{}, ctx.Err()
+}
@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type ClusterRolesReader interface {
 	Get(name string) (*ClusterRole, error)
-	List() ([]*ClusterRole, error)
+	List(opts ...resource.ListOption) ([]*ClusterRole, error)
+	ListPages(handler func([]*ClusterRole) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan ClusterRoleEvent, error)
+	WaitFor(ctx context.Context, predicate func(ClusterRoleEvent) (bool, error)) (ClusterRoleEvent, error)
+}
+
+type ClusterRoleEvent struct {
+	Type        resource.EventType
+	ClusterRole *ClusterRole
 }
 
 func NewClusterRolesReader(client resource.Client, filter resource.Filter) ClusterRolesReader {
@@ -30,24 +43,13 @@ type clusterRolesReader struct {
 }
 
 func (c *clusterRolesReader) Get(name string) (*ClusterRole, error) {
-	clusterRole := &rbacv1.ClusterRole{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.RbacV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), ClusterRoleKind.Scoped).
-		Resource(ClusterRoleResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(clusterRole)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, ClusterRoleKind.Scoped) {
+		clusterRole, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   ClusterRoleKind.Group,
 			Version: ClusterRoleKind.Version,
@@ -55,37 +57,123 @@ func (c *clusterRolesReader) Get(name string) (*ClusterRole, error) {
 		}, clusterRole.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewClusterRole(clusterRole, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    ClusterRoleKind.Group,
+		Resource: ClusterRoleResource.Name,
+	}, name)
+}
+
+func (c *clusterRolesReader) get(namespace, name string) (*rbacv1.ClusterRole, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, ClusterRoleKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    ClusterRoleKind.Group,
 				Resource: ClusterRoleResource.Name,
 			}, name)
 		}
+		return object.(*rbacv1.ClusterRole).DeepCopy(), nil
 	}
-	return NewClusterRole(clusterRole, c.Client), nil
-}
 
-func (c *clusterRolesReader) List() ([]*ClusterRole, error) {
-	list := &rbacv1.ClusterRoleList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &rbacv1.ClusterRole{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.RbacV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), ClusterRoleKind.Scoped).
+		NamespaceIfScoped(namespace, ClusterRoleKind.Scoped).
 		Resource(ClusterRoleResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*ClusterRole, 0, len(list.Items))
-	for _, clusterRole := range list.Items {
+func (c *clusterRolesReader) List(opts ...resource.ListOption) ([]*ClusterRole, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, ClusterRoleKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*ClusterRole, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *clusterRolesReader) ListPages(handler func([]*ClusterRole) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, ClusterRoleKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *clusterRolesReader) list(namespace string, options metav1.ListOptions) ([]*ClusterRole, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *clusterRolesReader) filterItems(items []rbacv1.ClusterRole) ([]*ClusterRole, error) {
+	results := make([]*ClusterRole, 0, len(items))
+	for _, clusterRole := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   ClusterRoleKind.Group,
 			Version: ClusterRoleKind.Version,
@@ -100,3 +188,145 @@ func (c *clusterRolesReader) List() ([]*ClusterRole, error) {
 	}
 	return results, nil
 }
+
+func (c *clusterRolesReader) listItems(namespace string, options metav1.ListOptions) ([]rbacv1.ClusterRole, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]rbacv1.ClusterRole, 0, len(objects))
+			for _, object := range objects {
+				clusterRole := object.(*rbacv1.ClusterRole)
+				if selector.Matches(labels.Set(clusterRole.Labels)) {
+					items = append(items, *clusterRole.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &rbacv1.ClusterRoleList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, ClusterRoleKind.Scoped).
+		Resource(ClusterRoleResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *clusterRolesReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(ClusterRoleKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &rbacv1.ClusterRoleList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, ClusterRoleKind.Scoped).
+					Resource(ClusterRoleResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, ClusterRoleKind.Scoped).
+					Resource(ClusterRoleResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &rbacv1.ClusterRole{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *clusterRolesReader) Watch(ctx context.Context) (<-chan ClusterRoleEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, ClusterRoleKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, ClusterRoleKind.Scoped).
+			Resource(ClusterRoleResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ClusterRoleEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			clusterRole, ok := watchEvent.Object.(*rbacv1.ClusterRole)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   ClusterRoleKind.Group,
+				Version: ClusterRoleKind.Version,
+				Kind:    ClusterRoleKind.Kind,
+			}, clusterRole.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- ClusterRoleEvent{Type: watchEvent.Type, ClusterRole: NewClusterRole(clusterRole, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *clusterRolesReader) WaitFor(ctx context.Context, predicate func(ClusterRoleEvent) (bool, error)) (ClusterRoleEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return ClusterRoleEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return ClusterRoleEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return ClusterRoleEvent{}, ctx.Err()
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var RoleResource = resource.Type{
 }
 
 func NewRole(role *rbacv1.Role, client resource.Client) *Role {
+	client = resource.ForNamespace(client, role.Namespace)
 	return &Role{
 		Resource: resource.NewResource(role.ObjectMeta, RoleKind, client),
 		Object:   role,
@@ -35,12 +37,11 @@ type Role struct {
 }
 
 func (r *Role) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.RbacV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, RoleKind.Scoped).
 		Resource(RoleResource.Name).
@@ -50,3 +51,94 @@ func (r *Role) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *Role) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &rbacv1.Role{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, RoleKind.Scoped).
+		Resource(RoleResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Role) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &rbacv1.Role{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, RoleKind.Scoped).
+		Resource(RoleResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *Role) Update(mutate func(*rbacv1.Role) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		role := r.Object.DeepCopy()
+		if err := mutate(role); err != nil {
+			return err
+		}
+		result := &rbacv1.Role{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, RoleKind.Scoped).
+			Resource(RoleResource.Name).
+			Name(r.Name).
+			Body(role).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *Role) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *Role) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *Role) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *Role) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -23,6 +24,7 @@ var RoleBindingResource = resource.Type{
 }
 
 func NewRoleBinding(roleBinding *rbacv1.RoleBinding, client resource.Client) *RoleBinding {
+	client = resource.ForNamespace(client, roleBinding.Namespace)
 	return &RoleBinding{
 		Resource: resource.NewResource(roleBinding.ObjectMeta, RoleBindingKind, client),
 		Object:   roleBinding,
@@ -35,12 +37,11 @@ type RoleBinding struct {
 }
 
 func (r *RoleBinding) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.RbacV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, RoleBindingKind.Scoped).
 		Resource(RoleBindingResource.Name).
@@ -50,3 +51,94 @@ func (r *RoleBinding) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *RoleBinding) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &rbacv1.RoleBinding{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, RoleBindingKind.Scoped).
+		Resource(RoleBindingResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *RoleBinding) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &rbacv1.RoleBinding{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, RoleBindingKind.Scoped).
+		Resource(RoleBindingResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *RoleBinding) Update(mutate func(*rbacv1.RoleBinding) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		roleBinding := r.Object.DeepCopy()
+		if err := mutate(roleBinding); err != nil {
+			return err
+		}
+		result := &rbacv1.RoleBinding{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, RoleBindingKind.Scoped).
+			Resource(RoleBindingResource.Name).
+			Name(r.Name).
+			Body(roleBinding).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *RoleBinding) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *RoleBinding) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *RoleBinding) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *RoleBinding) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
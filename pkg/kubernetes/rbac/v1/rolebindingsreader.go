@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type RoleBindingsReader interface {
 	Get(name string) (*RoleBinding, error)
-	List() ([]*RoleBinding, error)
+	List(opts ...resource.ListOption) ([]*RoleBinding, error)
+	ListPages(handler func([]*RoleBinding) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan RoleBindingEvent, error)
+	WaitFor(ctx context.Context, predicate func(RoleBindingEvent) (bool, error)) (RoleBindingEvent, error)
+}
+
+type RoleBindingEvent struct {
+	Type        resource.EventType
+	RoleBinding *RoleBinding
 }
 
 func NewRoleBindingsReader(client resource.Client, filter resource.Filter) RoleBindingsReader {
@@ -30,24 +43,13 @@ type roleBindingsReader struct {
 }
 
 func (c *roleBindingsReader) Get(name string) (*RoleBinding, error) {
-	roleBinding := &rbacv1.RoleBinding{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.RbacV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), RoleBindingKind.Scoped).
-		Resource(RoleBindingResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(roleBinding)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, RoleBindingKind.Scoped) {
+		roleBinding, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   RoleBindingKind.Group,
 			Version: RoleBindingKind.Version,
@@ -55,37 +57,123 @@ func (c *roleBindingsReader) Get(name string) (*RoleBinding, error) {
 		}, roleBinding.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewRoleBinding(roleBinding, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    RoleBindingKind.Group,
+		Resource: RoleBindingResource.Name,
+	}, name)
+}
+
+func (c *roleBindingsReader) get(namespace, name string) (*rbacv1.RoleBinding, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, RoleBindingKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    RoleBindingKind.Group,
 				Resource: RoleBindingResource.Name,
 			}, name)
 		}
+		return object.(*rbacv1.RoleBinding).DeepCopy(), nil
 	}
-	return NewRoleBinding(roleBinding, c.Client), nil
-}
 
-func (c *roleBindingsReader) List() ([]*RoleBinding, error) {
-	list := &rbacv1.RoleBindingList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &rbacv1.RoleBinding{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.RbacV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), RoleBindingKind.Scoped).
+		NamespaceIfScoped(namespace, RoleBindingKind.Scoped).
 		Resource(RoleBindingResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*RoleBinding, 0, len(list.Items))
-	for _, roleBinding := range list.Items {
+func (c *roleBindingsReader) List(opts ...resource.ListOption) ([]*RoleBinding, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, RoleBindingKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*RoleBinding, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *roleBindingsReader) ListPages(handler func([]*RoleBinding) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, RoleBindingKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *roleBindingsReader) list(namespace string, options metav1.ListOptions) ([]*RoleBinding, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *roleBindingsReader) filterItems(items []rbacv1.RoleBinding) ([]*RoleBinding, error) {
+	results := make([]*RoleBinding, 0, len(items))
+	for _, roleBinding := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   RoleBindingKind.Group,
 			Version: RoleBindingKind.Version,
@@ -100,3 +188,145 @@ func (c *roleBindingsReader) List() ([]*RoleBinding, error) {
 	}
 	return results, nil
 }
+
+func (c *roleBindingsReader) listItems(namespace string, options metav1.ListOptions) ([]rbacv1.RoleBinding, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]rbacv1.RoleBinding, 0, len(objects))
+			for _, object := range objects {
+				roleBinding := object.(*rbacv1.RoleBinding)
+				if selector.Matches(labels.Set(roleBinding.Labels)) {
+					items = append(items, *roleBinding.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &rbacv1.RoleBindingList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, RoleBindingKind.Scoped).
+		Resource(RoleBindingResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *roleBindingsReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(RoleBindingKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &rbacv1.RoleBindingList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, RoleBindingKind.Scoped).
+					Resource(RoleBindingResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, RoleBindingKind.Scoped).
+					Resource(RoleBindingResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &rbacv1.RoleBinding{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *roleBindingsReader) Watch(ctx context.Context) (<-chan RoleBindingEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, RoleBindingKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, RoleBindingKind.Scoped).
+			Resource(RoleBindingResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan RoleBindingEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			roleBinding, ok := watchEvent.Object.(*rbacv1.RoleBinding)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   RoleBindingKind.Group,
+				Version: RoleBindingKind.Version,
+				Kind:    RoleBindingKind.Kind,
+			}, roleBinding.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- RoleBindingEvent{Type: watchEvent.Type, RoleBinding: NewRoleBinding(roleBinding, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *roleBindingsReader) WaitFor(ctx context.Context, predicate func(RoleBindingEvent) (bool, error)) (RoleBindingEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return RoleBindingEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return RoleBindingEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return RoleBindingEvent{}, ctx.Err()
+}
@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type RolesReader interface {
 	Get(name string) (*Role, error)
-	List() ([]*Role, error)
+	List(opts ...resource.ListOption) ([]*Role, error)
+	ListPages(handler func([]*Role) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan RoleEvent, error)
+	WaitFor(ctx context.Context, predicate func(RoleEvent) (bool, error)) (RoleEvent, error)
+}
+
+type RoleEvent struct {
+	Type resource.EventType
+	Role *Role
 }
 
 func NewRolesReader(client resource.Client, filter resource.Filter) RolesReader {
@@ -30,24 +43,13 @@ type rolesReader struct {
 }
 
 func (c *rolesReader) Get(name string) (*Role, error) {
-	role := &rbacv1.Role{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.RbacV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), RoleKind.Scoped).
-		Resource(RoleResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(role)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, RoleKind.Scoped) {
+		role, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   RoleKind.Group,
 			Version: RoleKind.Version,
@@ -55,37 +57,123 @@ func (c *rolesReader) Get(name string) (*Role, error) {
 		}, role.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewRole(role, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    RoleKind.Group,
+		Resource: RoleResource.Name,
+	}, name)
+}
+
+func (c *rolesReader) get(namespace, name string) (*rbacv1.Role, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, RoleKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    RoleKind.Group,
 				Resource: RoleResource.Name,
 			}, name)
 		}
+		return object.(*rbacv1.Role).DeepCopy(), nil
 	}
-	return NewRole(role, c.Client), nil
-}
 
-func (c *rolesReader) List() ([]*Role, error) {
-	list := &rbacv1.RoleList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &rbacv1.Role{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.RbacV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), RoleKind.Scoped).
+		NamespaceIfScoped(namespace, RoleKind.Scoped).
 		Resource(RoleResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*Role, 0, len(list.Items))
-	for _, role := range list.Items {
+func (c *rolesReader) List(opts ...resource.ListOption) ([]*Role, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, RoleKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*Role, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *rolesReader) ListPages(handler func([]*Role) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, RoleKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *rolesReader) list(namespace string, options metav1.ListOptions) ([]*Role, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *rolesReader) filterItems(items []rbacv1.Role) ([]*Role, error) {
+	results := make([]*Role, 0, len(items))
+	for _, role := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   RoleKind.Group,
 			Version: RoleKind.Version,
@@ -100,3 +188,145 @@ func (c *rolesReader) List() ([]*Role, error) {
 	}
 	return results, nil
 }
+
+func (c *rolesReader) listItems(namespace string, options metav1.ListOptions) ([]rbacv1.Role, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]rbacv1.Role, 0, len(objects))
+			for _, object := range objects {
+				role := object.(*rbacv1.Role)
+				if selector.Matches(labels.Set(role.Labels)) {
+					items = append(items, *role.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &rbacv1.RoleList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, RoleKind.Scoped).
+		Resource(RoleResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *rolesReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(RoleKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &rbacv1.RoleList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, RoleKind.Scoped).
+					Resource(RoleResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, RoleKind.Scoped).
+					Resource(RoleResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &rbacv1.Role{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *rolesReader) Watch(ctx context.Context) (<-chan RoleEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, RoleKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, RoleKind.Scoped).
+			Resource(RoleResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan RoleEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			role, ok := watchEvent.Object.(*rbacv1.Role)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   RoleKind.Group,
+				Version: RoleKind.Version,
+				Kind:    RoleKind.Kind,
+			}, role.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- RoleEvent{Type: watchEvent.Type, Role: NewRole(role, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *rolesReader) WaitFor(ctx context.Context, predicate func(RoleEvent) (bool, error)) (RoleEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return RoleEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return RoleEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return RoleEvent{}, ctx.Err()
+}
@@ -0,0 +1,116 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"sync"
+	"time"
+)
+
+// CacheSyncTimeout is the maximum time to wait for a cache to sync
+var CacheSyncTimeout = time.Minute
+
+// Cached is implemented by clients that read resources from a Cache
+type Cached interface {
+	// Cache returns the client's cache or nil if the client is not cached
+	Cache() *Cache
+}
+
+// InformerFunc creates an informer
+type InformerFunc func() (cache.SharedIndexInformer, error)
+
+// NewCache returns a new resource cache. Informers are started lazily the first time a kind is read, and are
+// stopped when the given context is cancelled.
+func NewCache(ctx context.Context) *Cache {
+	return &Cache{
+		ctx:       ctx,
+		informers: make(map[cacheKey]cache.SharedIndexInformer),
+	}
+}
+
+// Cache is a cache of resources backed by shared informers. A cache may be shared by multiple clients, and
+// holds one informer per kind and namespace.
+type Cache struct {
+	ctx       context.Context
+	informers map[cacheKey]cache.SharedIndexInformer
+	mu        sync.Mutex
+}
+
+type cacheKey struct {
+	kind      Kind
+	namespace string
+}
+
+// Informer returns the informer for the given kind and namespace, starting it if necessary. Informer blocks
+// until the informer has synced.
+func (c *Cache) Informer(kind Kind, namespace string, newInformer InformerFunc) (cache.SharedIndexInformer, error) {
+	key := cacheKey{
+		kind:      kind,
+		namespace: namespace,
+	}
+
+	c.mu.Lock()
+	informer, ok := c.informers[key]
+	if !ok {
+		if err := c.ctx.Err(); err != nil {
+			c.mu.Unlock()
+			return nil, err
+		}
+		i, err := newInformer()
+		if err != nil {
+			c.mu.Unlock()
+			return nil, err
+		}
+		informer = i
+		c.informers[key] = informer
+		go informer.Run(c.ctx.Done())
+	}
+	c.mu.Unlock()
+
+	if !informer.HasSynced() {
+		ctx, cancel := context.WithTimeout(c.ctx, CacheSyncTimeout)
+		defer cancel()
+		if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+			return nil, fmt.Errorf("failed to sync %s cache", kind.Kind)
+		}
+	}
+	return informer, nil
+}
+
+// GetCacheKey returns the cache key for the given resource
+func GetCacheKey(namespace, name string, scoped bool) string {
+	if scoped && namespace != "" {
+		return namespace + "/" + name
+	}
+	return name
+}
+
+// GetCacheSelector returns the label selector with which to list resources from a cache for the given list options.
+// If the options cannot be served from a cache, ok is false.
+func GetCacheSelector(options metav1.ListOptions) (labels.Selector, bool, error) {
+	if options.FieldSelector != "" || options.ResourceVersion != "" || options.Limit != 0 || options.Continue != "" {
+		return nil, false, nil
+	}
+	selector, err := labels.Parse(options.LabelSelector)
+	if err != nil {
+		return nil, false, err
+	}
+	return selector, true, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := NewCache(ctx)
+
+	lists := 0
+	newInformer := func() (cache.SharedIndexInformer, error) {
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				lists++
+				return &corev1.PodList{
+					ListMeta: metav1.ListMeta{ResourceVersion: "1"},
+					Items:    []corev1.Pod{*newTestPod("1")},
+				}, nil
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return watch.NewFake(), nil
+			},
+		}, &corev1.Pod{}, 0, cache.Indexers{}), nil
+	}
+
+	kind := Kind{Version: "v1", Kind: "Pod", Scoped: true}
+	informer, err := c.Informer(kind, "test", newInformer)
+	assert.NoError(t, err)
+	assert.True(t, informer.HasSynced())
+	_, exists, err := informer.GetIndexer().GetByKey(GetCacheKey("", "foo", true))
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	shared, err := c.Informer(kind, "test", newInformer)
+	assert.NoError(t, err)
+	assert.Equal(t, informer, shared)
+	assert.Equal(t, 1, lists)
+}
+
+func TestCacheSelector(t *testing.T) {
+	selector, ok, err := GetCacheSelector(NewListOptions(WithLabelSelector("app=onos")))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "app=onos", selector.String())
+
+	_, ok, err = GetCacheSelector(NewListOptions(WithFieldSelector("status.phase=Running")))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = GetCacheSelector(NewListOptions(WithLimit(10)))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,122 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"sync"
+)
+
+// ClientsetFunc creates a typed clientset for the given configuration
+type ClientsetFunc func(config *rest.Config) (interface{}, error)
+
+// NewClientsets returns a new set of clients for the given configuration. If a clientset is provided it's
+// shared rather than creating a new clientset from the configuration. The dynamic client and discovery cache are
+// always created; clients that share all their clientsets share the Clientsets returned by GetClientsets.
+func NewClientsets(config *rest.Config, clientset kubernetes.Interface) (*Clientsets, error) {
+	if clientset == nil {
+		kubernetesClient, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		clientset = kubernetesClient
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Clientsets{
+		config:    config,
+		clientset: clientset,
+		dynamic:   dynamicClient,
+		mapper:    restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(clientset.Discovery())),
+		typed:     make(map[string]interface{}),
+	}, nil
+}
+
+// Clientsets is a set of clients shared by a client and all the readers and resources created from it
+type Clientsets struct {
+	config    *rest.Config
+	clientset kubernetes.Interface
+	dynamic   dynamic.Interface
+	mapper    *restmapper.DeferredDiscoveryRESTMapper
+	typed     map[string]interface{}
+	mu        sync.Mutex
+}
+
+// sharedClientsets is implemented by clients that embed Clientsets
+type sharedClientsets interface {
+	getClientsets() *Clientsets
+}
+
+func (c *Clientsets) getClientsets() *Clientsets {
+	return c
+}
+
+// GetClientsets returns the clientsets embedded in the given client, or nil if the client does not embed Clientsets
+func GetClientsets(client Client) *Clientsets {
+	if shared, ok := client.(sharedClientsets); ok {
+		return shared.getClientsets()
+	}
+	return nil
+}
+
+// Config returns the Kubernetes REST client configuration
+func (c *Clientsets) Config() *rest.Config {
+	return c.config
+}
+
+// Clientset returns the shared Kubernetes clientset
+func (c *Clientsets) Clientset() kubernetes.Interface {
+	return c.clientset
+}
+
+// DynamicClient returns the shared dynamic client
+func (c *Clientsets) DynamicClient() dynamic.Interface {
+	return c.dynamic
+}
+
+// RESTMapping returns the REST mapping for the given kind. Discovery results are cached, so if the kind is
+// unknown the cache is reset and the mapping retried once to pick up newly installed custom resources.
+func (c *Clientsets) RESTMapping(kind schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	mapping, err := c.mapper.RESTMapping(kind.GroupKind(), kind.Version)
+	if meta.IsNoMatchError(err) {
+		c.mapper.Reset()
+		mapping, err = c.mapper.RESTMapping(kind.GroupKind(), kind.Version)
+	}
+	return mapping, err
+}
+
+// TypedClientset returns the shared typed clientset with the given name, creating it if necessary
+func (c *Clientsets) TypedClientset(name string, newClientset ClientsetFunc) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clientset, ok := c.typed[name]
+	if !ok {
+		typed, err := newClientset(c.config)
+		if err != nil {
+			return nil, err
+		}
+		clientset = typed
+		c.typed[name] = clientset
+	}
+	return clientset, nil
+}
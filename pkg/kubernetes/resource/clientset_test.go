@@ -0,0 +1,67 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"testing"
+)
+
+func TestClientsets(t *testing.T) {
+	config := &rest.Config{
+		Host: "http://localhost:8080",
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	assert.NoError(t, err)
+
+	clientsets, err := NewClientsets(config, clientset)
+	assert.NoError(t, err)
+	assert.Same(t, config, clientsets.Config())
+	assert.Same(t, clientset, clientsets.Clientset())
+	assert.NotNil(t, clientsets.DynamicClient())
+
+	created := 0
+	newClientset := func(config *rest.Config) (interface{}, error) {
+		created++
+		return kubernetes.NewForConfig(config)
+	}
+	typed, err := clientsets.TypedClientset("test", newClientset)
+	assert.NoError(t, err)
+	shared, err := clientsets.TypedClientset("test", newClientset)
+	assert.NoError(t, err)
+	assert.Same(t, typed, shared)
+	assert.Equal(t, 1, created)
+}
+
+// clientsetsTestClient is a client that embeds clientsets
+type clientsetsTestClient struct {
+	*Clientsets
+}
+
+func (c *clientsetsTestClient) Namespace() string {
+	return "test"
+}
+
+func TestGetClientsets(t *testing.T) {
+	clientsets, err := NewClientsets(&rest.Config{Host: "http://localhost:8080"}, nil)
+	assert.NoError(t, err)
+
+	client := &clientsetsTestClient{Clientsets: clientsets}
+	assert.Same(t, clientsets, GetClientsets(client))
+	assert.Same(t, clientsets, GetClientsets(ForNamespace(client, "other")))
+	assert.Nil(t, GetClientsets(&namespaceTestClient{}))
+}
@@ -0,0 +1,57 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"sort"
+	"time"
+)
+
+// Events returns the events involving the resource, sorted by time
+func (r *Resource) Events() ([]corev1.Event, error) {
+	selector := fields.OneTermEqualSelector("involvedObject.uid", string(r.UID))
+	events, err := r.Clientset().CoreV1().Events(r.Namespace).List(metav1.ListOptions{
+		FieldSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	SortEvents(events.Items)
+	return events.Items, nil
+}
+
+// SortEvents sorts the given events by the time they last occurred
+func SortEvents(events []corev1.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return GetEventTime(events[i]).Before(GetEventTime(events[j]))
+	})
+}
+
+// GetEventTime returns the time the given event last occurred
+func GetEventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	if !event.FirstTimestamp.IsZero() {
+		return event.FirstTimestamp.Time
+	}
+	return event.CreationTimestamp.Time
+}
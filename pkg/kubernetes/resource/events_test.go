@@ -0,0 +1,45 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestSortEvents(t *testing.T) {
+	now := time.Now()
+	events := []corev1.Event{
+		{
+			ObjectMeta:    metav1.ObjectMeta{Name: "c"},
+			LastTimestamp: metav1.NewTime(now.Add(2 * time.Second)),
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b"},
+			EventTime:  metav1.NewMicroTime(now.Add(time.Second)),
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "a"},
+			FirstTimestamp: metav1.NewTime(now),
+		},
+	}
+	SortEvents(events)
+	assert.Equal(t, "a", events[0].Name)
+	assert.Equal(t, "b", events[1].Name)
+	assert.Equal(t, "c", events[2].Name)
+}
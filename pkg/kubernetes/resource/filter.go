@@ -0,0 +1,107 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"regexp"
+	"time"
+)
+
+// And returns a filter that accepts resources accepted by all the given filters
+func And(filters ...Filter) Filter {
+	return func(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
+		for _, filter := range filters {
+			if ok, err := filter(kind, meta); !ok || err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+}
+
+// Or returns a filter that accepts resources accepted by any of the given filters
+func Or(filters ...Filter) Filter {
+	return func(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
+		for _, filter := range filters {
+			if ok, err := filter(kind, meta); ok || err != nil {
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+}
+
+// Not returns a filter that accepts resources rejected by the given filter
+func Not(filter Filter) Filter {
+	return func(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
+		ok, err := filter(kind, meta)
+		if err != nil {
+			return false, err
+		}
+		return !ok, nil
+	}
+}
+
+// LabelSelector returns a filter that accepts resources with labels matching the given selector, e.g. "role=leader"
+func LabelSelector(selector string) Filter {
+	parsed, parseErr := labels.Parse(selector)
+	return func(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
+		if parseErr != nil {
+			return false, parseErr
+		}
+		return parsed.Matches(labels.Set(meta.Labels)), nil
+	}
+}
+
+// Namespace returns a filter that accepts resources in the given namespace
+func Namespace(namespace string) Filter {
+	return func(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
+		return meta.Namespace == namespace, nil
+	}
+}
+
+// NameRegex returns a filter that accepts resources with names matching the given regular expression
+func NameRegex(pattern string) Filter {
+	regex, compileErr := regexp.Compile(pattern)
+	return func(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
+		if compileErr != nil {
+			return false, compileErr
+		}
+		return regex.MatchString(meta.Name), nil
+	}
+}
+
+// Kinds returns a filter that accepts resources of the given kinds. A kind with an empty version matches all
+// versions of the kind.
+func Kinds(kinds ...schema.GroupVersionKind) Filter {
+	return func(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
+		for _, k := range kinds {
+			if k.Group == kind.Group && k.Kind == kind.Kind && (k.Version == "" || k.Version == kind.Version) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// CreatedAfter returns a filter that accepts resources created after the given time
+func CreatedAfter(t time.Time) Filter {
+	return func(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
+		return meta.CreationTimestamp.Time.After(t), nil
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"testing"
+	"time"
+)
+
+var podKind = metav1.GroupVersionKind{
+	Version: "v1",
+	Kind:    "Pod",
+}
+
+func newFilterMeta(name string, labels map[string]string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace:         "onos",
+		Name:              name,
+		Labels:            labels,
+		CreationTimestamp: metav1.NewTime(time.Unix(1000, 0)),
+	}
+}
+
+func assertFilter(t *testing.T, expected bool, filter Filter, meta metav1.ObjectMeta) {
+	ok, err := filter(podKind, meta)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, ok)
+}
+
+func TestFilterCombinators(t *testing.T) {
+	leader := newFilterMeta("onos-0", map[string]string{"role": "leader"})
+	follower := newFilterMeta("onos-1", map[string]string{"role": "follower"})
+	reject := Not(NoFilter)
+
+	assertFilter(t, true, And(), leader)
+	assertFilter(t, true, And(NoFilter, LabelSelector("role=leader")), leader)
+	assertFilter(t, false, And(NoFilter, LabelSelector("role=leader")), follower)
+	assertFilter(t, false, Or(), leader)
+	assertFilter(t, true, Or(reject, NameRegex("-1$")), follower)
+	assertFilter(t, false, Or(reject, NameRegex("-1$")), leader)
+	assertFilter(t, true, Not(LabelSelector("role in (follower)")), leader)
+
+	failed := errors.New("failed")
+	var fail Filter = func(kind metav1.GroupVersionKind, meta metav1.ObjectMeta) (bool, error) {
+		return false, failed
+	}
+	_, err := And(NoFilter, fail)(podKind, leader)
+	assert.Equal(t, failed, err)
+	_, err = Or(reject, fail)(podKind, leader)
+	assert.Equal(t, failed, err)
+	_, err = Not(fail)(podKind, leader)
+	assert.Equal(t, failed, err)
+
+	// Short-circuited filters are not evaluated
+	assertFilter(t, false, And(reject, fail), leader)
+	assertFilter(t, true, Or(NoFilter, fail), leader)
+}
+
+func TestFilterConstructors(t *testing.T) {
+	meta := newFilterMeta("onos-0", map[string]string{"app": "onos"})
+
+	assertFilter(t, true, Namespace("onos"), meta)
+	assertFilter(t, false, Namespace("default"), meta)
+	assertFilter(t, true, NameRegex("^onos-[0-9]+$"), meta)
+	assertFilter(t, false, NameRegex("^atomix"), meta)
+	assertFilter(t, true, CreatedAfter(time.Unix(999, 0)), meta)
+	assertFilter(t, false, CreatedAfter(time.Unix(1000, 0)), meta)
+	assertFilter(t, true, Kinds(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}, schema.GroupVersionKind{Kind: "Pod"}), meta)
+	assertFilter(t, false, Kinds(schema.GroupVersionKind{Version: "v2", Kind: "Pod"}), meta)
+
+	_, err := LabelSelector("app in (")(podKind, meta)
+	assert.Error(t, err)
+	_, err = NameRegex("onos-(")(podKind, meta)
+	assert.Error(t, err)
+}
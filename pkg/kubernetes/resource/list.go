@@ -0,0 +1,73 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultPageSize is the default number of resources requested per page by ListPages
+const DefaultPageSize int64 = 500
+
+// ListOption is an option for listing resources
+type ListOption func(*metav1.ListOptions)
+
+// WithLabelSelector returns a list option that selects resources matching the given label selector
+func WithLabelSelector(selector string) ListOption {
+	return func(options *metav1.ListOptions) {
+		options.LabelSelector = selector
+	}
+}
+
+// WithFieldSelector returns a list option that selects resources matching the given field selector
+func WithFieldSelector(selector string) ListOption {
+	return func(options *metav1.ListOptions) {
+		options.FieldSelector = selector
+	}
+}
+
+// WithResourceVersion returns a list option that lists resources at the given resource version
+func WithResourceVersion(version string) ListOption {
+	return func(options *metav1.ListOptions) {
+		options.ResourceVersion = version
+	}
+}
+
+// WithLimit returns a list option that limits the number of resources returned by the API server.
+// Note that the limit is applied before resources are filtered by the client. For clients that read several
+// namespaces, the limit applies to the resources returned across all the namespaces; use ListPages to read them all.
+func WithLimit(limit int64) ListOption {
+	return func(options *metav1.ListOptions) {
+		options.Limit = limit
+	}
+}
+
+// NewListOptions returns the list options for the given options
+func NewListOptions(opts ...ListOption) metav1.ListOptions {
+	options := metav1.ListOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// NewPageOptions returns the list options for the given options with a default page size
+func NewPageOptions(opts ...ListOption) metav1.ListOptions {
+	options := NewListOptions(opts...)
+	if options.Limit == 0 {
+		options.Limit = DefaultPageSize
+	}
+	return options
+}
@@ -0,0 +1,35 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestListOptions(t *testing.T) {
+	options := NewListOptions(
+		WithLabelSelector("app=onos"),
+		WithFieldSelector("status.phase=Running"),
+		WithResourceVersion("1"),
+		WithLimit(10))
+	assert.Equal(t, "app=onos", options.LabelSelector)
+	assert.Equal(t, "status.phase=Running", options.FieldSelector)
+	assert.Equal(t, "1", options.ResourceVersion)
+	assert.Equal(t, int64(10), options.Limit)
+
+	assert.Equal(t, DefaultPageSize, NewPageOptions().Limit)
+	assert.Equal(t, int64(10), NewPageOptions(WithLimit(10)).Limit)
+}
@@ -0,0 +1,128 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"k8s.io/apimachinery/pkg/watch"
+	"sync"
+)
+
+// MultiNamespaced is implemented by clients that read namespaced resources from more than one namespace
+type MultiNamespaced interface {
+	// Namespaces returns the namespaces from which the client reads namespaced resources
+	Namespaces() []string
+}
+
+// GetNamespaces returns the namespaces from which the given client reads resources. Cluster scoped resources are
+// read cluster-wide. Namespaced resources are read from the namespaces of a MultiNamespaced client, or otherwise from
+// the client namespace.
+func GetNamespaces(client Client, scoped bool) []string {
+	if !scoped {
+		return []string{""}
+	}
+	if namespaced, ok := client.(MultiNamespaced); ok {
+		if namespaces := namespaced.Namespaces(); len(namespaces) > 0 {
+			return namespaces
+		}
+	}
+	return []string{client.Namespace()}
+}
+
+// ForNamespace returns a client that reads and writes resources in the given namespace. Resources read through the
+// returned client are served from the parent client's cache, if it has one.
+func ForNamespace(client Client, namespace string) Client {
+	if namespace == "" || (namespace == client.Namespace() && !isMultiNamespaced(client)) {
+		return client
+	}
+	return &namespacedClient{
+		Client:    client,
+		namespace: namespace,
+	}
+}
+
+func isMultiNamespaced(client Client) bool {
+	_, ok := client.(MultiNamespaced)
+	return ok
+}
+
+// namespacedClient overrides the namespace of a client
+type namespacedClient struct {
+	Client
+	namespace string
+}
+
+func (c *namespacedClient) Namespace() string {
+	return c.namespace
+}
+
+func (c *namespacedClient) Cache() *Cache {
+	if cached, ok := c.Client.(Cached); ok {
+		return cached.Cache()
+	}
+	return nil
+}
+
+func (c *namespacedClient) getClientsets() *Clientsets {
+	return GetClientsets(c.Client)
+}
+
+// NamespacedWatchFunc starts a watch in the given namespace from the given resource version
+type NamespacedWatchFunc func(namespace, resourceVersion string) (watch.Interface, error)
+
+// WatchNamespaces starts a watch in each of the given namespaces and merges their events into a single channel. The
+// channel is closed once the context is cancelled.
+func WatchNamespaces(ctx context.Context, namespaces []string, start NamespacedWatchFunc) (<-chan WatchEvent, error) {
+	if len(namespaces) == 1 {
+		return Watch(ctx, func(resourceVersion string) (watch.Interface, error) {
+			return start(namespaces[0], resourceVersion)
+		})
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	watches := make([]<-chan WatchEvent, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		namespace := namespace
+		events, err := Watch(ctx, func(resourceVersion string) (watch.Interface, error) {
+			return start(namespace, resourceVersion)
+		})
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		watches = append(watches, events)
+	}
+
+	ch := make(chan WatchEvent)
+	wg := &sync.WaitGroup{}
+	wg.Add(len(watches))
+	for _, events := range watches {
+		go func(events <-chan WatchEvent) {
+			defer wg.Done()
+			for event := range events {
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+				}
+			}
+		}(events)
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+		close(ch)
+	}()
+	return ch, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"testing"
+)
+
+type namespaceTestClient struct {
+	Client
+	namespaces []string
+	cache      *Cache
+}
+
+func (c *namespaceTestClient) Namespace() string {
+	return "test"
+}
+
+func (c *namespaceTestClient) Namespaces() []string {
+	return c.namespaces
+}
+
+func (c *namespaceTestClient) Cache() *Cache {
+	return c.cache
+}
+
+func TestGetNamespaces(t *testing.T) {
+	client := &namespaceTestClient{}
+	assert.Equal(t, []string{"test"}, GetNamespaces(client, true))
+	assert.Equal(t, []string{""}, GetNamespaces(client, false))
+
+	client.namespaces = []string{"test", "other"}
+	assert.Equal(t, []string{"test", "other"}, GetNamespaces(client, true))
+	assert.Equal(t, []string{""}, GetNamespaces(client, false))
+}
+
+func TestForNamespace(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := &namespaceTestClient{
+		namespaces: []string{"test", "other"},
+		cache:      NewCache(ctx),
+	}
+
+	namespaced := ForNamespace(client, "other")
+	assert.Equal(t, "other", namespaced.Namespace())
+	assert.Equal(t, []string{"other"}, GetNamespaces(namespaced, true))
+	assert.Equal(t, client.cache, namespaced.(Cached).Cache())
+
+	namespaced = ForNamespace(client, "test")
+	assert.Equal(t, []string{"test"}, GetNamespaces(namespaced, true))
+
+	// Cluster scoped objects are read through the parent client
+	assert.Equal(t, client, ForNamespace(client, ""))
+}
+
+func TestWatchNamespaces(t *testing.T) {
+	watchers := make(map[string]*watch.FakeWatcher)
+	start := func(namespace, resourceVersion string) (watch.Interface, error) {
+		watcher := watch.NewFake()
+		watchers[namespace] = watcher
+		return watcher, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := WatchNamespaces(ctx, []string{"test", "other"}, start)
+	assert.NoError(t, err)
+	assert.Len(t, watchers, 2)
+
+	go watchers["test"].Add(newTestPod("1"))
+	event := <-events
+	assert.Equal(t, "1", event.Object.(*corev1.Pod).ResourceVersion)
+
+	go watchers["other"].Add(newTestPod("2"))
+	event = <-events
+	assert.Equal(t, "2", event.Object.(*corev1.Pod).ResourceVersion)
+
+	cancel()
+	for range events {
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+)
+
+// NewLabelPatch returns a JSON merge patch that sets the given label. If the value is nil, the label is removed.
+func NewLabelPatch(key string, value *string) []byte {
+	return newMetadataPatch("labels", key, value)
+}
+
+// NewAnnotationPatch returns a JSON merge patch that sets the given annotation. If the value is nil,
+// the annotation is removed.
+func NewAnnotationPatch(key string, value *string) []byte {
+	return newMetadataPatch("annotations", key, value)
+}
+
+func newMetadataPatch(field string, key string, value *string) []byte {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			field: map[string]*string{
+				key: value,
+			},
+		},
+	}
+	bytes, err := json.Marshal(patch)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
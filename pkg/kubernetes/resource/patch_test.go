@@ -0,0 +1,28 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMetadataPatch(t *testing.T) {
+	value := "bar"
+	assert.Equal(t, `{"metadata":{"labels":{"foo":"bar"}}}`, string(NewLabelPatch("foo", &value)))
+	assert.Equal(t, `{"metadata":{"labels":{"foo":null}}}`, string(NewLabelPatch("foo", nil)))
+	assert.Equal(t, `{"metadata":{"annotations":{"foo":"bar"}}}`, string(NewAnnotationPatch("foo", &value)))
+	assert.Equal(t, `{"metadata":{"annotations":{"foo":null}}}`, string(NewAnnotationPatch("foo", nil)))
+}
@@ -15,8 +15,11 @@
 package resource
 
 import (
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"time"
@@ -38,6 +41,9 @@ type Kind struct {
 
 // Waiter is an interface for resources that support waiting for readiness
 type Waiter interface {
+	// Ready returns whether the resource is ready
+	Ready() (bool, error)
+	// Wait waits for the resource to become ready
 	Wait(time.Duration) error
 }
 
@@ -72,7 +78,16 @@ type Client interface {
 	Config() *rest.Config
 
 	// Clientset returns the client's Clientset
-	Clientset() *kubernetes.Clientset
+	Clientset() kubernetes.Interface
+
+	// DynamicClient returns the client's dynamic client
+	DynamicClient() dynamic.Interface
+
+	// RESTMapping returns the REST mapping for the given kind, refreshing discovery if the kind is unknown
+	RESTMapping(kind schema.GroupVersionKind) (*meta.RESTMapping, error)
+
+	// TypedClientset returns the client's typed clientset with the given name, creating it if necessary
+	TypedClientset(name string, newClientset ClientsetFunc) (interface{}, error)
 }
 
 // NewResource creates a new resource
@@ -0,0 +1,43 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"time"
+)
+
+// WaitInterval is the interval at which readiness is polled while waiting
+var WaitInterval = time.Second
+
+// ReadyFunc is a function that checks whether a resource is ready
+type ReadyFunc func() (bool, error)
+
+// Wait polls the given readiness check until it succeeds or the timeout expires
+func Wait(ready ReadyFunc, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return WaitContext(ctx, ready)
+}
+
+// WaitContext polls the given readiness check until it succeeds or the context is done
+func WaitContext(ctx context.Context, ready ReadyFunc) error {
+	err := wait.PollImmediateUntil(WaitInterval, wait.ConditionFunc(ready), ctx.Done())
+	if err == wait.ErrWaitTimeout && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
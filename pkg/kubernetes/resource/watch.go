@@ -0,0 +1,125 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"time"
+)
+
+// EventType is a resource event type
+type EventType string
+
+const (
+	// EventAdded indicates a resource was added
+	EventAdded EventType = "Added"
+	// EventModified indicates a resource was modified
+	EventModified EventType = "Modified"
+	// EventDeleted indicates a resource was deleted
+	EventDeleted EventType = "Deleted"
+)
+
+// WatchEvent is an untyped resource event
+type WatchEvent struct {
+	Type   EventType
+	Object runtime.Object
+}
+
+// WatchFunc starts a watch from the given resource version
+type WatchFunc func(resourceVersion string) (watch.Interface, error)
+
+// Watch starts a watch and returns a channel of events. When the server closes the watch it is restarted from the
+// last observed resource version. The channel is closed once the context is cancelled.
+func Watch(ctx context.Context, start WatchFunc) (<-chan WatchEvent, error) {
+	watcher, err := start("")
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan WatchEvent)
+	go func() {
+		defer close(ch)
+		resourceVersion := ""
+		for {
+			resourceVersion = receive(ctx, watcher, ch, resourceVersion)
+			watcher.Stop()
+			for {
+				if ctx.Err() != nil {
+					return
+				}
+				watcher, err = start(resourceVersion)
+				if err == nil {
+					break
+				} else if errors.IsGone(err) || errors.IsResourceExpired(err) {
+					resourceVersion = ""
+				}
+				select {
+				case <-time.After(WaitInterval):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// receive forwards events from the given watcher until it's closed, returning the resource version from
+// which to restart the watch
+func receive(ctx context.Context, watcher watch.Interface, ch chan<- WatchEvent, resourceVersion string) string {
+	for {
+		select {
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion
+			}
+
+			var eventType EventType
+			switch event.Type {
+			case watch.Added:
+				eventType = EventAdded
+			case watch.Modified:
+				eventType = EventModified
+			case watch.Deleted:
+				eventType = EventDeleted
+			case watch.Error:
+				err := errors.FromObject(event.Object)
+				if errors.IsGone(err) || errors.IsResourceExpired(err) {
+					return ""
+				}
+				return resourceVersion
+			}
+
+			if accessor, err := meta.Accessor(event.Object); err == nil {
+				resourceVersion = accessor.GetResourceVersion()
+			}
+			if eventType == "" {
+				continue
+			}
+
+			select {
+			case ch <- WatchEvent{Type: eventType, Object: event.Object}:
+			case <-ctx.Done():
+				return resourceVersion
+			}
+		case <-ctx.Done():
+			return resourceVersion
+		}
+	}
+}
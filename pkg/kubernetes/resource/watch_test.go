@@ -0,0 +1,78 @@
+// Copyright 2020-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"testing"
+)
+
+func newTestPod(resourceVersion string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "foo",
+			ResourceVersion: resourceVersion,
+		},
+	}
+}
+
+func TestWatch(t *testing.T) {
+	watchers := make(chan *watch.FakeWatcher, 3)
+	versions := make(chan string, 3)
+	start := func(resourceVersion string) (watch.Interface, error) {
+		watcher := watch.NewFake()
+		watchers <- watcher
+		versions <- resourceVersion
+		return watcher, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := Watch(ctx, start)
+	assert.NoError(t, err)
+	watcher := <-watchers
+	assert.Equal(t, "", <-versions)
+
+	go watcher.Add(newTestPod("1"))
+	event := <-events
+	assert.Equal(t, EventAdded, event.Type)
+	assert.Equal(t, "1", event.Object.(*corev1.Pod).ResourceVersion)
+
+	go watcher.Modify(newTestPod("2"))
+	event = <-events
+	assert.Equal(t, EventModified, event.Type)
+
+	// Closing the watch should restart it from the last resource version
+	watcher.Stop()
+	watcher = <-watchers
+	assert.Equal(t, "2", <-versions)
+
+	go watcher.Delete(newTestPod("3"))
+	event = <-events
+	assert.Equal(t, EventDeleted, event.Type)
+
+	// An expired resource version should restart the watch from the current state
+	go watcher.Error(&errors.NewGone("expired").ErrStatus)
+	<-watchers
+	assert.Equal(t, "", <-versions)
+
+	cancel()
+	_, ok := <-events
+	assert.False(t, ok)
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"time"
 )
 
@@ -14,7 +15,7 @@ var StorageClassKind = resource.Kind{
 	Group:   "storage.k8s.io",
 	Version: "v1",
 	Kind:    "StorageClass",
-	Scoped:  true,
+	Scoped:  false,
 }
 
 var StorageClassResource = resource.Type{
@@ -23,6 +24,7 @@ var StorageClassResource = resource.Type{
 }
 
 func NewStorageClass(storageClass *storagev1.StorageClass, client resource.Client) *StorageClass {
+	client = resource.ForNamespace(client, storageClass.Namespace)
 	return &StorageClass{
 		Resource: resource.NewResource(storageClass.ObjectMeta, StorageClassKind, client),
 		Object:   storageClass,
@@ -35,12 +37,11 @@ type StorageClass struct {
 }
 
 func (r *StorageClass) Delete() error {
-	client, err := kubernetes.NewForConfig(r.Config())
+	client, err := getRESTClient(r.Client)
 	if err != nil {
 		return err
 	}
-	return client.StorageV1().
-		RESTClient().
+	return client.
 		Delete().
 		NamespaceIfScoped(r.Namespace, StorageClassKind.Scoped).
 		Resource(StorageClassResource.Name).
@@ -50,3 +51,94 @@ func (r *StorageClass) Delete() error {
 		Do().
 		Error()
 }
+
+func (r *StorageClass) Refresh() error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &storagev1.StorageClass{}
+	err = client.
+		Get().
+		NamespaceIfScoped(r.Namespace, StorageClassKind.Scoped).
+		Resource(StorageClassResource.Name).
+		Name(r.Name).
+		VersionedParams(&metav1.GetOptions{}, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *StorageClass) Patch(patchType types.PatchType, data []byte) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	result := &storagev1.StorageClass{}
+	err = client.
+		Patch(patchType).
+		NamespaceIfScoped(r.Namespace, StorageClassKind.Scoped).
+		Resource(StorageClassResource.Name).
+		Name(r.Name).
+		Body(data).
+		Timeout(time.Minute).
+		Do().
+		Into(result)
+	if err != nil {
+		return err
+	}
+	r.Object = result
+	return nil
+}
+
+func (r *StorageClass) Update(mutate func(*storagev1.StorageClass) error) error {
+	client, err := getRESTClient(r.Client)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Refresh(); err != nil {
+			return err
+		}
+		storageClass := r.Object.DeepCopy()
+		if err := mutate(storageClass); err != nil {
+			return err
+		}
+		result := &storagev1.StorageClass{}
+		err := client.
+			Put().
+			NamespaceIfScoped(r.Namespace, StorageClassKind.Scoped).
+			Resource(StorageClassResource.Name).
+			Name(r.Name).
+			Body(storageClass).
+			Timeout(time.Minute).
+			Do().
+			Into(result)
+		if err != nil {
+			return err
+		}
+		r.Object = result
+		return nil
+	})
+}
+
+func (r *StorageClass) SetLabel(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, &value))
+}
+
+func (r *StorageClass) RemoveLabel(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewLabelPatch(key, nil))
+}
+
+func (r *StorageClass) SetAnnotation(key, value string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, &value))
+}
+
+func (r *StorageClass) RemoveAnnotation(key string) error {
+	return r.Patch(types.MergePatchType, resource.NewAnnotationPatch(key, nil))
+}
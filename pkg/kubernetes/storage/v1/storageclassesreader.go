@@ -3,18 +3,31 @@
 package v1
 
 import (
+	"context"
 	"github.com/onosproject/helm-go/pkg/kubernetes/resource"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sort"
 	"time"
 )
 
 type StorageClassesReader interface {
 	Get(name string) (*StorageClass, error)
-	List() ([]*StorageClass, error)
+	List(opts ...resource.ListOption) ([]*StorageClass, error)
+	ListPages(handler func([]*StorageClass) (bool, error), opts ...resource.ListOption) error
+	Watch(ctx context.Context) (<-chan StorageClassEvent, error)
+	WaitFor(ctx context.Context, predicate func(StorageClassEvent) (bool, error)) (StorageClassEvent, error)
+}
+
+type StorageClassEvent struct {
+	Type         resource.EventType
+	StorageClass *StorageClass
 }
 
 func NewStorageClassesReader(client resource.Client, filter resource.Filter) StorageClassesReader {
@@ -30,24 +43,13 @@ type storageClassesReader struct {
 }
 
 func (c *storageClassesReader) Get(name string) (*StorageClass, error) {
-	storageClass := &storagev1.StorageClass{}
-	client, err := kubernetes.NewForConfig(c.Config())
-	if err != nil {
-		return nil, err
-	}
-	err = client.StorageV1().
-		RESTClient().
-		Get().
-		NamespaceIfScoped(c.Namespace(), StorageClassKind.Scoped).
-		Resource(StorageClassResource.Name).
-		Name(name).
-		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
-		Timeout(time.Minute).
-		Do().
-		Into(storageClass)
-	if err != nil {
-		return nil, err
-	} else {
+	for _, namespace := range resource.GetNamespaces(c.Client, StorageClassKind.Scoped) {
+		storageClass, err := c.get(namespace, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   StorageClassKind.Group,
 			Version: StorageClassKind.Version,
@@ -55,37 +57,123 @@ func (c *storageClassesReader) Get(name string) (*StorageClass, error) {
 		}, storageClass.ObjectMeta)
 		if err != nil {
 			return nil, err
-		} else if !ok {
+		} else if ok {
+			return NewStorageClass(storageClass, c.Client), nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{
+		Group:    StorageClassKind.Group,
+		Resource: StorageClassResource.Name,
+	}, name)
+}
+
+func (c *storageClassesReader) get(namespace, name string) (*storagev1.StorageClass, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, err
+	} else if informer != nil {
+		object, exists, err := informer.GetIndexer().GetByKey(resource.GetCacheKey(namespace, name, StorageClassKind.Scoped))
+		if err != nil {
+			return nil, err
+		} else if !exists {
 			return nil, errors.NewNotFound(schema.GroupResource{
 				Group:    StorageClassKind.Group,
 				Resource: StorageClassResource.Name,
 			}, name)
 		}
+		return object.(*storagev1.StorageClass).DeepCopy(), nil
 	}
-	return NewStorageClass(storageClass, c.Client), nil
-}
 
-func (c *storageClassesReader) List() ([]*StorageClass, error) {
-	list := &storagev1.StorageClassList{}
-	client, err := kubernetes.NewForConfig(c.Config())
+	object := &storagev1.StorageClass{}
+	client, err := getRESTClient(c.Client)
 	if err != nil {
 		return nil, err
 	}
-	err = client.StorageV1().
-		RESTClient().
+	err = client.
 		Get().
-		NamespaceIfScoped(c.Namespace(), StorageClassKind.Scoped).
+		NamespaceIfScoped(namespace, StorageClassKind.Scoped).
 		Resource(StorageClassResource.Name).
+		Name(name).
 		VersionedParams(&metav1.ListOptions{}, metav1.ParameterCodec).
 		Timeout(time.Minute).
 		Do().
-		Into(list)
+		Into(object)
 	if err != nil {
 		return nil, err
 	}
+	return object, nil
+}
 
-	results := make([]*StorageClass, 0, len(list.Items))
-	for _, storageClass := range list.Items {
+func (c *storageClassesReader) List(opts ...resource.ListOption) ([]*StorageClass, error) {
+	options := resource.NewListOptions(opts...)
+	namespaces := resource.GetNamespaces(c.Client, StorageClassKind.Scoped)
+	if len(namespaces) == 1 {
+		results, _, err := c.list(namespaces[0], options)
+		return results, err
+	}
+	// The limit applies to the objects returned by the API server across all the namespaces, so each namespace
+	// is only asked for the objects that remain
+	results := make([]*StorageClass, 0)
+	remaining := options.Limit
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Limit = remaining
+		items, _, err := c.listItems(namespace, namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		namespaceResults, err := c.filterItems(items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, namespaceResults...)
+		if options.Limit > 0 {
+			remaining -= int64(len(items))
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *storageClassesReader) ListPages(handler func([]*StorageClass) (bool, error), opts ...resource.ListOption) error {
+	// Continue tokens are only valid within a namespace, so each namespace is paged through in turn
+	for _, namespace := range resource.GetNamespaces(c.Client, StorageClassKind.Scoped) {
+		options := resource.NewPageOptions(opts...)
+		for {
+			results, next, err := c.list(namespace, options)
+			if err != nil {
+				return err
+			}
+			if ok, err := handler(results); err != nil {
+				return err
+			} else if !ok {
+				return nil
+			} else if next == "" {
+				break
+			}
+			options.Continue = next
+		}
+	}
+	return nil
+}
+
+func (c *storageClassesReader) list(namespace string, options metav1.ListOptions) ([]*StorageClass, string, error) {
+	items, next, err := c.listItems(namespace, options)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := c.filterItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, next, nil
+}
+
+func (c *storageClassesReader) filterItems(items []storagev1.StorageClass) ([]*StorageClass, error) {
+	results := make([]*StorageClass, 0, len(items))
+	for _, storageClass := range items {
 		ok, err := c.filter(metav1.GroupVersionKind{
 			Group:   StorageClassKind.Group,
 			Version: StorageClassKind.Version,
@@ -100,3 +188,145 @@ func (c *storageClassesReader) List() ([]*StorageClass, error) {
 	}
 	return results, nil
 }
+
+func (c *storageClassesReader) listItems(namespace string, options metav1.ListOptions) ([]storagev1.StorageClass, string, error) {
+	informer, err := c.informer(namespace)
+	if err != nil {
+		return nil, "", err
+	} else if informer != nil {
+		selector, ok, err := resource.GetCacheSelector(options)
+		if err != nil {
+			return nil, "", err
+		} else if ok {
+			objects := informer.GetIndexer().List()
+			items := make([]storagev1.StorageClass, 0, len(objects))
+			for _, object := range objects {
+				storageClass := object.(*storagev1.StorageClass)
+				if selector.Matches(labels.Set(storageClass.Labels)) {
+					items = append(items, *storageClass.DeepCopy())
+				}
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].Name < items[j].Name
+			})
+			return items, "", nil
+		}
+	}
+
+	list := &storagev1.StorageClassList{}
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, "", err
+	}
+	err = client.
+		Get().
+		NamespaceIfScoped(namespace, StorageClassKind.Scoped).
+		Resource(StorageClassResource.Name).
+		VersionedParams(&options, metav1.ParameterCodec).
+		Timeout(time.Minute).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, "", err
+	}
+	return list.Items, list.Continue, nil
+}
+
+func (c *storageClassesReader) informer(namespace string) (cache.SharedIndexInformer, error) {
+	cached, ok := c.Client.(resource.Cached)
+	if !ok || cached.Cache() == nil {
+		return nil, nil
+	}
+	return cached.Cache().Informer(StorageClassKind, namespace, func() (cache.SharedIndexInformer, error) {
+		client, err := getRESTClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list := &storagev1.StorageClassList{}
+				err := client.
+					Get().
+					NamespaceIfScoped(namespace, StorageClassKind.Scoped).
+					Resource(StorageClassResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Timeout(time.Minute).
+					Do().
+					Into(list)
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				return client.
+					Get().
+					NamespaceIfScoped(namespace, StorageClassKind.Scoped).
+					Resource(StorageClassResource.Name).
+					VersionedParams(&options, metav1.ParameterCodec).
+					Watch()
+			},
+		}, &storagev1.StorageClass{}, 0, cache.Indexers{}), nil
+	})
+}
+
+func (c *storageClassesReader) Watch(ctx context.Context) (<-chan StorageClassEvent, error) {
+	client, err := getRESTClient(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := resource.GetNamespaces(c.Client, StorageClassKind.Scoped)
+	events, err := resource.WatchNamespaces(ctx, namespaces, func(namespace, resourceVersion string) (watch.Interface, error) {
+		return client.
+			Get().
+			NamespaceIfScoped(namespace, StorageClassKind.Scoped).
+			Resource(StorageClassResource.Name).
+			VersionedParams(&metav1.ListOptions{Watch: true, ResourceVersion: resourceVersion}, metav1.ParameterCodec).
+			Context(ctx).
+			Watch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan StorageClassEvent)
+	go func() {
+		defer close(ch)
+		for watchEvent := range events {
+			storageClass, ok := watchEvent.Object.(*storagev1.StorageClass)
+			if !ok {
+				continue
+			}
+			ok, err := c.filter(metav1.GroupVersionKind{
+				Group:   StorageClassKind.Group,
+				Version: StorageClassKind.Version,
+				Kind:    StorageClassKind.Kind,
+			}, storageClass.ObjectMeta)
+			if err != nil || !ok {
+				continue
+			}
+			select {
+			case ch <- StorageClassEvent{Type: watchEvent.Type, StorageClass: NewStorageClass(storageClass, c.Client)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *storageClassesReader) WaitFor(ctx context.Context, predicate func(StorageClassEvent) (bool, error)) (StorageClassEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.Watch(ctx)
+	if err != nil {
+		return StorageClassEvent{}, err
+	}
+	for event := range events {
+		ok, err := predicate(event)
+		if err != nil {
+			return StorageClassEvent{}, err
+		} else if ok {
+			return event, nil
+		}
+	}
+	return StorageClassEvent{}, ctx.Err()
+}